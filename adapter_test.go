@@ -0,0 +1,87 @@
+package tree
+
+import (
+	"iter"
+)
+
+// keyAdapter wraps a tree with keys of type K as a Tree with Uint64Keys so that
+// it can run the generated tests and benchmarks. The key conversions must
+// preserve the order of the keys used by the tests.
+type keyAdapter[K any] struct {
+	tree   TreeOf[K, interface{}]
+	encode func(uint64) K
+	decode func(K) uint64
+}
+
+func (ka *keyAdapter[K]) key(key Key) K {
+	return ka.encode(uint64(key.(Uint64Key)))
+}
+
+func (ka *keyAdapter[K]) entry(key K, value interface{}, ok bool) (Key, interface{}, bool) {
+	if !ok {
+		return nil, nil, false
+	}
+	return Uint64Key(ka.decode(key)), value, true
+}
+
+func (ka *keyAdapter[K]) seq(seq iter.Seq2[K, interface{}]) iter.Seq2[Key, interface{}] {
+	return func(yield func(Key, interface{}) bool) {
+		for key, value := range seq {
+			if !yield(Uint64Key(ka.decode(key)), value) {
+				return
+			}
+		}
+	}
+}
+
+func (ka *keyAdapter[K]) Get(key Key) (interface{}, bool) {
+	return ka.tree.Get(ka.key(key))
+}
+
+func (ka *keyAdapter[K]) Set(key Key, value interface{}) (interface{}, bool) {
+	return ka.tree.Set(ka.key(key), value)
+}
+
+func (ka *keyAdapter[K]) Del(key Key) (interface{}, bool) {
+	return ka.tree.Del(ka.key(key))
+}
+
+func (ka *keyAdapter[K]) Ascend() iter.Seq2[Key, interface{}] {
+	return ka.seq(ka.tree.Ascend())
+}
+
+func (ka *keyAdapter[K]) Descend() iter.Seq2[Key, interface{}] {
+	return ka.seq(ka.tree.Descend())
+}
+
+func (ka *keyAdapter[K]) Range(lo, hi Key) iter.Seq2[Key, interface{}] {
+	return ka.seq(ka.tree.Range(ka.key(lo), ka.key(hi)))
+}
+
+func (ka *keyAdapter[K]) Min() (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Min())
+}
+
+func (ka *keyAdapter[K]) Max() (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Max())
+}
+
+func (ka *keyAdapter[K]) Floor(key Key) (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Floor(ka.key(key)))
+}
+
+func (ka *keyAdapter[K]) Ceiling(key Key) (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Ceiling(ka.key(key)))
+}
+
+func (ka *keyAdapter[K]) Predecessor(key Key) (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Predecessor(ka.key(key)))
+}
+
+func (ka *keyAdapter[K]) Successor(key Key) (Key, interface{}, bool) {
+	return ka.entry(ka.tree.Successor(ka.key(key)))
+}
+
+func (ka *keyAdapter[K]) Len() int {
+	return ka.tree.Len()
+}
@@ -0,0 +1,581 @@
+package tree
+
+// Adaptive radix trie implementation.
+
+import (
+	"iter"
+	"math"
+)
+
+// Adaptive radix trie parameters. artWidth is the number of bits indexed at
+// each level, artCount is the number of slots in the largest node, and
+// artLimit is the highest level.
+const (
+	artWidth = 8
+	artCount = 1 << artWidth
+	artLimit = 64/artWidth - 1
+)
+
+// Adaptive radix trie.
+type artTrie[V any] struct {
+	root artTrieNode
+
+	// Number of keys in the trie.
+	size int
+}
+
+// Either an *artLeaf or an artNode.
+type artTrieNode interface{}
+
+// Inner node in an adaptive radix trie. There is one implementation for each
+// range of child counts: Node4, Node16, Node48, and Node256. Every inner node
+// has at least two children.
+type artNode[V any] interface {
+	header() *artHeader
+
+	// child returns the slot containing the child for the given byte, or nil
+	// if there is no such child.
+	child(b byte) *artTrieNode
+
+	// add adds a child for a byte which does not have one and returns the
+	// node, which is replaced with a larger one if it was full.
+	add(b byte, child artTrieNode) artNode[V]
+
+	// remove removes the child for a byte which has one and returns the node,
+	// which is replaced with a smaller one if it became sparse enough.
+	remove(b byte) artNode[V]
+
+	// each calls fn on every child in ascending order of bytes, or descending
+	// order if reverse is true, until fn returns false. It returns false if fn
+	// returned false.
+	each(reverse bool, fn func(artTrieNode) bool) bool
+}
+
+// Fields common to every inner node.
+type artHeader struct {
+	// Bits of the keys underneath the node above its level.
+	key uint64
+
+	// The node's children are indexed by the byte of their keys at this
+	// level.
+	level uint
+
+	// Number of children.
+	count uint
+}
+
+// Inner node with up to 4 children, with keys kept sorted.
+type artNode4[V any] struct {
+	artHeader
+	keys     [4]byte
+	children [4]artTrieNode
+}
+
+// Inner node with up to 16 children, with keys kept sorted.
+type artNode16[V any] struct {
+	artHeader
+	keys     [16]byte
+	children [16]artTrieNode
+}
+
+// Inner node with up to 48 children.
+type artNode48[V any] struct {
+	artHeader
+
+	// One more than the index in children of the child for each byte, or 0
+	// if the byte has no child.
+	index    [artCount]uint8
+	children [48]artTrieNode
+}
+
+// Inner node with up to 256 children.
+type artNode256[V any] struct {
+	artHeader
+	children [artCount]artTrieNode
+}
+
+// Leaf containing a value in an adaptive radix trie.
+type artLeaf[V any] struct {
+	key   uint64
+	value V
+}
+
+// NewAdaptiveRadixTrie creates an empty adaptive radix trie. Like the
+// path-compressed radix trie, it skips levels with only one child, but it
+// indexes by bytes and sizes each node for the number of children it has, so
+// sparse key sets use much less memory. Time complexity is O(m), where m is the
+// number of bytes in the key (8).
+func NewAdaptiveRadixTrie() Trie {
+	return NewAdaptiveRadixTrieOf[interface{}]()
+}
+
+// NewAdaptiveRadixTrieOf creates an empty adaptive radix trie with values of
+// type V. See NewAdaptiveRadixTrie.
+func NewAdaptiveRadixTrieOf[V any]() TrieOf[V] {
+	return new(artTrie[V])
+}
+
+func (art *artTrie[V]) Get(key uint64) (V, bool) {
+	node := art.root
+
+	for node != nil {
+		if leaf, ok := node.(*artLeaf[V]); ok {
+			if leaf.key == key {
+				return leaf.value, true
+			} else {
+				break
+			}
+		}
+
+		anode := node.(artNode[V])
+		h := anode.header()
+		if h.notDescendant(key) {
+			break
+		}
+		slot := anode.child(artSlot(key, h.level))
+		if slot == nil {
+			break
+		}
+		node = *slot
+	}
+	var value V
+	return value, false
+}
+
+func (art *artTrie[V]) Set(key uint64, value V) (V, bool) {
+	if art.root == nil {
+		art.root = &artLeaf[V]{key, value}
+		art.size++
+		var origValue V
+		return origValue, false
+	}
+
+	node := art.root
+	parent := &art.root
+
+	for {
+		var other uint64
+		if leaf, ok := node.(*artLeaf[V]); ok {
+			if leaf.key == key {
+				origValue := leaf.value
+				leaf.value = value
+				return origValue, true
+			}
+			other = leaf.key
+		} else {
+			anode := node.(artNode[V])
+			h := anode.header()
+			if !h.notDescendant(key) {
+				b := artSlot(key, h.level)
+				slot := anode.child(b)
+				if slot == nil {
+					*parent = anode.add(b, &artLeaf[V]{key, value})
+					art.size++
+					var origValue V
+					return origValue, false
+				}
+				parent = slot
+				node = *slot
+				continue
+			}
+			other = h.key
+		}
+
+		// The key diverges from the node, so put them both under a new node.
+		level := artDiffLevel(key, other)
+		newNode := &artNode4[V]{artHeader: artHeader{key: artTrimKey(key, level+1), level: level}}
+		newNode.add(artSlot(key, level), &artLeaf[V]{key, value})
+		newNode.add(artSlot(other, level), node)
+		*parent = newNode
+		art.size++
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (art *artTrie[V]) Del(key uint64) (V, bool) {
+	if leaf, ok := art.root.(*artLeaf[V]); ok {
+		if leaf.key == key {
+			art.root = nil
+			art.size--
+			return leaf.value, true
+		}
+		var value V
+		return value, false
+	}
+
+	node := art.root
+	parent := &art.root
+
+	for node != nil {
+		anode := node.(artNode[V])
+		h := anode.header()
+		if h.notDescendant(key) {
+			break
+		}
+		b := artSlot(key, h.level)
+		slot := anode.child(b)
+		if slot == nil {
+			break
+		}
+
+		leaf, ok := (*slot).(*artLeaf[V])
+		if !ok {
+			parent = slot
+			node = *slot
+			continue
+		}
+		if leaf.key != key {
+			break
+		}
+
+		anode = anode.remove(b)
+		if anode.header().count == 1 {
+			// Path compression: replace the node with its only child.
+			anode.each(false, func(child artTrieNode) bool {
+				*parent = child
+				return false
+			})
+		} else {
+			*parent = anode
+		}
+		art.size--
+		return leaf.value, true
+	}
+
+	var value V
+	return value, false
+}
+
+func (art *artTrie[V]) Ascend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		art.walk(0, math.MaxUint64, false, yield)
+	}
+}
+
+func (art *artTrie[V]) Descend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		art.walk(0, math.MaxUint64, true, yield)
+	}
+}
+
+func (art *artTrie[V]) Range(lo, hi uint64) iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		if lo < hi {
+			art.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (art *artTrie[V]) Min() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).min()
+}
+
+func (art *artTrie[V]) Max() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).max()
+}
+
+func (art *artTrie[V]) Floor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).floor(key)
+}
+
+func (art *artTrie[V]) Ceiling(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).ceiling(key)
+}
+
+func (art *artTrie[V]) Predecessor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).predecessor(key)
+}
+
+func (art *artTrie[V]) Successor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](art.walk).successor(key)
+}
+
+func (art *artTrie[V]) Len() int {
+	return art.size
+}
+
+func (art *artTrie[V]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	trieWalkFunc[uint64, V](art.walk).prefix(prefix, bits, fn)
+}
+
+func (art *artTrie[V]) CountPrefix(prefix uint64, bits uint) int {
+	return trieWalkFunc[uint64, V](art.walk).countPrefix(prefix, bits)
+}
+
+func (art *artTrie[V]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	return artWalk(art.root, lo, hi, reverse, yield)
+}
+
+// artWalk calls yield on every leaf underneath the given node with a key
+// between lo and hi, inclusive. See radixWalk.
+func artWalk[V any](node artTrieNode, lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	switch node := node.(type) {
+	case *artLeaf[V]:
+		if node.key < lo || node.key > hi {
+			return true
+		}
+		return yield(node.key, node.value)
+	case artNode[V]:
+		h := node.header()
+		if h.last() < lo || h.key > hi {
+			return true
+		}
+		return node.each(reverse, func(child artTrieNode) bool {
+			return artWalk(child, lo, hi, reverse, yield)
+		})
+	}
+	return true
+}
+
+// artSlot returns the byte of the key at the given level.
+func artSlot(key uint64, level uint) byte {
+	return byte(key >> (level * artWidth))
+}
+
+// artTrimKey trims a key after the given level.
+func artTrimKey(key uint64, level uint) uint64 {
+	key >>= level * artWidth
+	key <<= level * artWidth
+	return key
+}
+
+// artDiffLevel finds the highest level at which the keys differ.
+func artDiffLevel(key1, key2 uint64) uint {
+	if key1 == key2 {
+		panic("equal keys")
+	}
+	return uint(63-clz(key1^key2)) / artWidth
+}
+
+func (h *artHeader) header() *artHeader {
+	return h
+}
+
+// notDescendant returns true if the given key can be determined to not be
+// underneath the node.
+func (h *artHeader) notDescendant(key uint64) bool {
+	if h.level < artLimit {
+		return artTrimKey(key, h.level+1) != h.key
+	} else {
+		return false
+	}
+}
+
+// last returns the largest key which could be underneath the node.
+func (h *artHeader) last() uint64 {
+	return h.key | (1<<((h.level+1)*artWidth) - 1)
+}
+
+func (n *artNode4[V]) child(b byte) *artTrieNode {
+	return artSortedChild(n.keys[:n.count], n.children[:n.count], b)
+}
+
+func (n *artNode4[V]) add(b byte, child artTrieNode) artNode[V] {
+	if n.count == 4 {
+		grown := &artNode16[V]{artHeader: n.artHeader}
+		copy(grown.keys[:], n.keys[:])
+		copy(grown.children[:], n.children[:])
+		return grown.add(b, child)
+	}
+	artSortedAdd(n.keys[:n.count+1], n.children[:n.count+1], b, child)
+	n.count++
+	return n
+}
+
+func (n *artNode4[V]) remove(b byte) artNode[V] {
+	artSortedRemove(n.keys[:n.count], n.children[:n.count], b)
+	n.count--
+	return n
+}
+
+func (n *artNode4[V]) each(reverse bool, fn func(artTrieNode) bool) bool {
+	return artSortedEach(n.children[:n.count], reverse, fn)
+}
+
+func (n *artNode16[V]) child(b byte) *artTrieNode {
+	return artSortedChild(n.keys[:n.count], n.children[:n.count], b)
+}
+
+func (n *artNode16[V]) add(b byte, child artTrieNode) artNode[V] {
+	if n.count == 16 {
+		grown := &artNode48[V]{artHeader: n.artHeader}
+		for i := range n.keys {
+			grown.index[n.keys[i]] = uint8(i + 1)
+		}
+		copy(grown.children[:], n.children[:])
+		return grown.add(b, child)
+	}
+	artSortedAdd(n.keys[:n.count+1], n.children[:n.count+1], b, child)
+	n.count++
+	return n
+}
+
+func (n *artNode16[V]) remove(b byte) artNode[V] {
+	artSortedRemove(n.keys[:n.count], n.children[:n.count], b)
+	n.count--
+	if n.count == 3 {
+		shrunk := &artNode4[V]{artHeader: n.artHeader}
+		copy(shrunk.keys[:], n.keys[:3])
+		copy(shrunk.children[:], n.children[:3])
+		return shrunk
+	}
+	return n
+}
+
+func (n *artNode16[V]) each(reverse bool, fn func(artTrieNode) bool) bool {
+	return artSortedEach(n.children[:n.count], reverse, fn)
+}
+
+func (n *artNode48[V]) child(b byte) *artTrieNode {
+	if i := n.index[b]; i != 0 {
+		return &n.children[i-1]
+	}
+	return nil
+}
+
+func (n *artNode48[V]) add(b byte, child artTrieNode) artNode[V] {
+	if n.count == 48 {
+		grown := &artNode256[V]{artHeader: n.artHeader}
+		for i, j := range n.index {
+			if j != 0 {
+				grown.children[i] = n.children[j-1]
+			}
+		}
+		return grown.add(b, child)
+	}
+	i := 0
+	for n.children[i] != nil {
+		i++
+	}
+	n.children[i] = child
+	n.index[b] = uint8(i + 1)
+	n.count++
+	return n
+}
+
+func (n *artNode48[V]) remove(b byte) artNode[V] {
+	n.children[n.index[b]-1] = nil
+	n.index[b] = 0
+	n.count--
+	if n.count == 12 {
+		shrunk := &artNode16[V]{artHeader: n.artHeader}
+		i := 0
+		for b, j := range n.index {
+			if j != 0 {
+				shrunk.keys[i] = byte(b)
+				shrunk.children[i] = n.children[j-1]
+				i++
+			}
+		}
+		return shrunk
+	}
+	return n
+}
+
+func (n *artNode48[V]) each(reverse bool, fn func(artTrieNode) bool) bool {
+	for i := 0; i < artCount; i++ {
+		b := i
+		if reverse {
+			b = artCount - 1 - i
+		}
+		if j := n.index[b]; j != 0 && !fn(n.children[j-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *artNode256[V]) child(b byte) *artTrieNode {
+	if n.children[b] != nil {
+		return &n.children[b]
+	}
+	return nil
+}
+
+func (n *artNode256[V]) add(b byte, child artTrieNode) artNode[V] {
+	n.children[b] = child
+	n.count++
+	return n
+}
+
+func (n *artNode256[V]) remove(b byte) artNode[V] {
+	n.children[b] = nil
+	n.count--
+	if n.count == 37 {
+		shrunk := &artNode48[V]{artHeader: n.artHeader}
+		i := 0
+		for b, child := range n.children {
+			if child != nil {
+				shrunk.index[b] = uint8(i + 1)
+				shrunk.children[i] = child
+				i++
+			}
+		}
+		return shrunk
+	}
+	return n
+}
+
+func (n *artNode256[V]) each(reverse bool, fn func(artTrieNode) bool) bool {
+	for i := 0; i < artCount; i++ {
+		b := i
+		if reverse {
+			b = artCount - 1 - i
+		}
+		if n.children[b] != nil && !fn(n.children[b]) {
+			return false
+		}
+	}
+	return true
+}
+
+// artSortedChild finds the child for the given byte in a node with sorted keys.
+func artSortedChild(keys []byte, children []artTrieNode, b byte) *artTrieNode {
+	for i, k := range keys {
+		if k == b {
+			return &children[i]
+		} else if k > b {
+			break
+		}
+	}
+	return nil
+}
+
+// artSortedAdd inserts a child into a node with sorted keys. The last element
+// of the slices is unused and is shifted into.
+func artSortedAdd(keys []byte, children []artTrieNode, b byte, child artTrieNode) {
+	i := len(keys) - 1
+	for ; i > 0 && keys[i-1] > b; i-- {
+		keys[i] = keys[i-1]
+		children[i] = children[i-1]
+	}
+	keys[i] = b
+	children[i] = child
+}
+
+// artSortedRemove removes a child from a node with sorted keys and clears the
+// last element of the slices.
+func artSortedRemove(keys []byte, children []artTrieNode, b byte) {
+	i := 0
+	for keys[i] != b {
+		i++
+	}
+	copy(keys[i:], keys[i+1:])
+	copy(children[i:], children[i+1:])
+	children[len(children)-1] = nil
+}
+
+// artSortedEach calls fn on the children of a node with sorted keys. See
+// artNode.each.
+func artSortedEach(children []artTrieNode, reverse bool, fn func(artTrieNode) bool) bool {
+	for i := range children {
+		if reverse {
+			i = len(children) - 1 - i
+		}
+		if !fn(children[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package tree
+
+import (
+	"testing"
+)
+
+// artNodeKind returns the name of the type of an inner node.
+func artNodeKind(node artTrieNode) string {
+	switch node.(type) {
+	case *artNode4[interface{}]:
+		return "Node4"
+	case *artNode16[interface{}]:
+		return "Node16"
+	case *artNode48[interface{}]:
+		return "Node48"
+	case *artNode256[interface{}]:
+		return "Node256"
+	default:
+		return "leaf"
+	}
+}
+
+func TestARTNodeSizes(t *testing.T) {
+	art := NewAdaptiveRadixTrie().(*artTrie[interface{}])
+
+	// Keys which differ only in the lowest byte share a single node.
+	expectedKind := func(count int) string {
+		switch {
+		case count <= 1:
+			return "leaf"
+		case count <= 4:
+			return "Node4"
+		case count <= 16:
+			return "Node16"
+		case count <= 48:
+			return "Node48"
+		default:
+			return "Node256"
+		}
+	}
+	for i := 0; i < artCount; i++ {
+		art.Set(0x1234500+uint64(i), i)
+		if kind := artNodeKind(art.root); kind != expectedKind(i+1) {
+			t.Fatalf("set failed: got %v with %v keys, expected %v\n", kind, i+1, expectedKind(i+1))
+		}
+	}
+
+	// Nodes shrink later than they grow so that alternating sets and
+	// deletes do not resize them every time.
+	shrinkKind := func(count int) string {
+		switch {
+		case count <= 1:
+			return "leaf"
+		case count <= 3:
+			return "Node4"
+		case count <= 12:
+			return "Node16"
+		case count <= 37:
+			return "Node48"
+		default:
+			return "Node256"
+		}
+	}
+	for _, v := range testRand.Perm(artCount) {
+		if _, ok := art.Del(0x1234500 + uint64(v)); !ok {
+			t.Fatalf("delete failed: %v was not in trie\n", v)
+		}
+		count := art.Len()
+		if count == 0 {
+			if art.root != nil {
+				t.Fatalf("delete failed: root left in empty trie\n")
+			}
+		} else if kind := artNodeKind(art.root); kind != shrinkKind(count) {
+			t.Fatalf("delete failed: got %v with %v keys, expected %v\n", kind, count, shrinkKind(count))
+		}
+
+		// The remaining keys must stay in order.
+		prev := -1
+		for k, v := range art.Ascend() {
+			if int(k)-0x1234500 <= prev || v != int(k)-0x1234500 {
+				t.Fatalf("ascend failed: got %x, %v after %v\n", k, v, prev)
+			}
+			prev = v.(int)
+		}
+	}
+}
+
+// benchmarkCreateSparse builds a trie with random 64-bit keys, which leaves
+// most nodes with few children, and reports the memory used.
+func benchmarkCreateSparse(b *testing.B, newTrie func() Trie) {
+	keys := make([]uint64, NUM_NODES)
+	for i := range keys {
+		keys[i] = testRand.Uint64()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie := newTrie()
+		for _, k := range keys {
+			trie.Set(k, nil)
+		}
+	}
+}
+
+func BenchmarkRadixTrieCreateSparse(b *testing.B) {
+	benchmarkCreateSparse(b, func() Trie { return NewRadixTrie() })
+}
+
+func BenchmarkARTCreateSparse(b *testing.B) {
+	benchmarkCreateSparse(b, NewAdaptiveRadixTrie)
+}
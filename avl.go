@@ -0,0 +1,123 @@
+package tree
+
+// AVL tree implementation.
+
+import (
+	"cmp"
+)
+
+// AVL tree.
+type avlTree[K, V any] struct {
+	// Underlying binary search tree. The balance of each node is the height of
+	// its subtree, where a leaf has height zero.
+	binarySearchTree[K, V]
+}
+
+// NewAVL creates an empty AVL tree. An AVL tree is a self-balancing binary
+// search tree which keeps the heights of the two subtrees of every node within
+// one of each other, so all operations are O(log n) in the worst case. The
+// returned tree is an OrderStatisticTree.
+func NewAVL() Tree {
+	return NewAVLOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewAVLOf creates an empty AVL tree ordered by the natural order of its keys.
+// See NewAVL.
+func NewAVLOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewAVLOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewAVLOfFunc creates an empty AVL tree ordered by the given comparison
+// function. See NewAVL and NewBSTOfFunc.
+func NewAVLOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	avl := new(avlTree[K, V])
+	avl.compare = compare
+	return avl
+}
+
+// NewAVLFunc creates an empty AVL tree ordered by the given comparison
+// function. See NewAVL and NewBSTFunc.
+func NewAVLFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewAVLOfFunc[interface{}, interface{}](compare)
+}
+
+func (avl *avlTree[K, V]) Set(key K, value V) (V, bool) {
+	node, exists := avl.add(key)
+
+	if exists {
+		origValue := node.value
+		node.value = value
+		return origValue, true
+	} else {
+		node.value = value
+		avl.rebalance(node.parent)
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (avl *avlTree[K, V]) Del(key K) (V, bool) {
+	node := avl.get(key)
+
+	if node == nil {
+		var value V
+		return value, false
+	} else {
+		avl.rebalance(avl.remove(node))
+		return node.value, true
+	}
+}
+
+// rebalance restores the AVL property on the given node and all of its
+// ancestors after a change underneath it.
+func (avl *avlTree[K, V]) rebalance(node *bstNode[K, V]) {
+	for node != nil {
+		node.updateHeight()
+
+		switch node.left.height() - node.right.height() {
+		case 2:
+			// Left-right case: make it a left-left case first.
+			if node.left.right.height() > node.left.left.height() {
+				avl.rotateLeft(node.left)
+			}
+			avl.rotateRight(node)
+			node = node.parent
+		case -2:
+			// Right-left case: make it a right-right case first.
+			if node.right.left.height() > node.right.right.height() {
+				avl.rotateRight(node.right)
+			}
+			avl.rotateLeft(node)
+			node = node.parent
+		}
+
+		node = node.parent
+	}
+}
+
+func (avl *avlTree[K, V]) rotateLeft(node *bstNode[K, V]) {
+	avl.binarySearchTree.rotateLeft(node)
+	node.updateHeight()
+	node.parent.updateHeight()
+}
+
+func (avl *avlTree[K, V]) rotateRight(node *bstNode[K, V]) {
+	avl.binarySearchTree.rotateRight(node)
+	node.updateHeight()
+	node.parent.updateHeight()
+}
+
+// height returns the height of the subtree rooted at this node, or -1 if the
+// node is nil.
+func (node *bstNode[K, V]) height() int {
+	if node == nil {
+		return -1
+	} else {
+		return node.balance
+	}
+}
+
+// updateHeight recomputes the height of this node from its children.
+func (node *bstNode[K, V]) updateHeight() {
+	node.balance = 1 + max(node.left.height(), node.right.height())
+}
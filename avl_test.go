@@ -0,0 +1,39 @@
+package tree
+
+import (
+	"testing"
+)
+
+// checkAVL checks the AVL property of a subtree and returns its height.
+func checkAVL(t *testing.T, node *bstNode[Key, interface{}]) int {
+	if node == nil {
+		return -1
+	}
+
+	left := checkAVL(t, node.left)
+	right := checkAVL(t, node.right)
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("unbalanced node %v: left height %v, right height %v\n",
+			node.key, left, right)
+	}
+	if height := 1 + max(left, right); node.balance != height {
+		t.Fatalf("incorrect height for %v: got %v, expected %v\n",
+			node.key, node.balance, height)
+	}
+	return node.balance
+}
+
+func TestAVLBalanced(t *testing.T) {
+	avl := NewAVL().(*avlTree[Key, interface{}])
+
+	// Sorted insertion degenerates an unbalanced tree into a list.
+	for i := 0; i < NUM_NODES; i++ {
+		avl.Set(Uint64Key(i), i)
+	}
+	checkAVL(t, avl.root)
+
+	for _, v := range testRand.Perm(NUM_NODES)[:NUM_NODES/2] {
+		avl.Del(Uint64Key(v))
+		checkAVL(t, avl.root)
+	}
+}
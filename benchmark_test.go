@@ -185,6 +185,198 @@ func BenchmarkSplayCreateRandom(b *testing.B) {
 	benchmarkCreateRandom(b, NewSplay())
 }
 
+// AVL tree.
+func BenchmarkAVLRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewAVL())
+}
+func BenchmarkAVLRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewAVL())
+}
+func BenchmarkAVLCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewAVL())
+}
+func BenchmarkAVLLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewAVL())
+}
+func BenchmarkAVLRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewAVL())
+}
+func BenchmarkAVLCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewAVL())
+}
+func BenchmarkAVLLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewAVL())
+}
+func BenchmarkAVLLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewAVL())
+}
+func BenchmarkAVLCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewAVL())
+}
+func BenchmarkAVLCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewAVL())
+}
+
+// Red-black tree.
+func BenchmarkRedBlackRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewRedBlack())
+}
+func BenchmarkRedBlackRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewRedBlack())
+}
+func BenchmarkRedBlackCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewRedBlack())
+}
+func BenchmarkRedBlackLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewRedBlack())
+}
+func BenchmarkRedBlackRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewRedBlack())
+}
+func BenchmarkRedBlackCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewRedBlack())
+}
+func BenchmarkRedBlackLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewRedBlack())
+}
+func BenchmarkRedBlackLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewRedBlack())
+}
+func BenchmarkRedBlackCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewRedBlack())
+}
+func BenchmarkRedBlackCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewRedBlack())
+}
+
+// Treap.
+func BenchmarkTreapRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewTreap())
+}
+func BenchmarkTreapRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewTreap())
+}
+func BenchmarkTreapCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewTreap())
+}
+func BenchmarkTreapLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewTreap())
+}
+func BenchmarkTreapRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewTreap())
+}
+func BenchmarkTreapCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewTreap())
+}
+func BenchmarkTreapLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewTreap())
+}
+func BenchmarkTreapLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewTreap())
+}
+func BenchmarkTreapCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewTreap())
+}
+func BenchmarkTreapCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewTreap())
+}
+
+// B-tree.
+func BenchmarkBTreeRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewBTree(16))
+}
+func BenchmarkBTreeRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewBTree(16))
+}
+func BenchmarkBTreeCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewBTree(16))
+}
+func BenchmarkBTreeLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewBTree(16))
+}
+func BenchmarkBTreeRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewBTree(16))
+}
+func BenchmarkBTreeCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewBTree(16))
+}
+func BenchmarkBTreeLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewBTree(16))
+}
+func BenchmarkBTreeLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewBTree(16))
+}
+func BenchmarkBTreeCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewBTree(16))
+}
+func BenchmarkBTreeCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewBTree(16))
+}
+
+// Skip list.
+func BenchmarkSkipListRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewSkipList())
+}
+func BenchmarkSkipListRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewSkipList())
+}
+func BenchmarkSkipListCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewSkipList())
+}
+func BenchmarkSkipListLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewSkipList())
+}
+func BenchmarkSkipListRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewSkipList())
+}
+func BenchmarkSkipListCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewSkipList())
+}
+func BenchmarkSkipListLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewSkipList())
+}
+func BenchmarkSkipListLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewSkipList())
+}
+func BenchmarkSkipListCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewSkipList())
+}
+func BenchmarkSkipListCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewSkipList())
+}
+
+// Concurrent skip list.
+func BenchmarkConcurrentSkipListRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewConcurrentSkipList())
+}
+func BenchmarkConcurrentSkipListCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewConcurrentSkipList())
+}
+
 // Simple trie.
 func BenchmarkSimpleTrieRandomGet(b *testing.B) {
 	benchmarkRandomGet(b, NewTreeFromTrie(NewBinaryTrie()))
@@ -280,3 +472,291 @@ func BenchmarkRadixTrieCreateRandomLarge(b *testing.B) {
 func BenchmarkRadixTrieCreateRandom(b *testing.B) {
 	benchmarkCreateRandom(b, NewTreeFromTrie(NewRadixTrie()))
 }
+
+// Radix trie of width 2.
+func BenchmarkRadixTrie2RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+func BenchmarkRadixTrie2CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewTreeFromTrie(NewRadixTrieWidth(2)))
+}
+
+// Radix trie of width 8.
+func BenchmarkRadixTrie8RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func BenchmarkRadixTrie8CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+
+// Concurrent radix trie.
+func BenchmarkConcurrentRadixTrieRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func BenchmarkConcurrentRadixTrieCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+
+// Adaptive radix trie.
+func BenchmarkARTRandomGet(b *testing.B) {
+	benchmarkRandomGet(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTRandomDel(b *testing.B) {
+	benchmarkRandomDel(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTCreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTLocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTRandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTCreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTLocalGet(b *testing.B) {
+	benchmarkLocalGet(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTLocalDel(b *testing.B) {
+	benchmarkLocalDel(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTCreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func BenchmarkARTCreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+
+// 32-bit binary trie.
+func BenchmarkBinaryTrie32RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+func BenchmarkBinaryTrie32CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, newTrie32Adapter(NewBinaryTrie32()))
+}
+
+// 32-bit CLZ trie.
+func BenchmarkCLZTrie32RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, newTrie32Adapter(NewCLZTrie32()))
+}
+func BenchmarkCLZTrie32CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, newTrie32Adapter(NewCLZTrie32()))
+}
+
+// 32-bit radix trie.
+func BenchmarkRadixTrie32RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, newTrie32Adapter(NewRadixTrie32()))
+}
+func BenchmarkRadixTrie32CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, newTrie32Adapter(NewRadixTrie32()))
+}
+
+// 128-bit binary trie.
+func BenchmarkBinaryTrie128RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+func BenchmarkBinaryTrie128CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, newTrie128Adapter(NewBinaryTrie128()))
+}
+
+// 128-bit radix trie.
+func BenchmarkRadixTrie128RandomGet(b *testing.B) {
+	benchmarkRandomGet(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128RandomDel(b *testing.B) {
+	benchmarkRandomDel(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128CreateBalancedLarge(b *testing.B) {
+	benchmarkCreateBalancedLarge(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128LocalGetLarge(b *testing.B) {
+	benchmarkLocalGetLarge(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128RandomGetLarge(b *testing.B) {
+	benchmarkRandomGetLarge(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128CreateBalanced(b *testing.B) {
+	benchmarkCreateBalanced(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128LocalGet(b *testing.B) {
+	benchmarkLocalGet(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128LocalDel(b *testing.B) {
+	benchmarkLocalDel(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128CreateRandomLarge(b *testing.B) {
+	benchmarkCreateRandomLarge(b, newTrie128Adapter(NewRadixTrie128()))
+}
+func BenchmarkRadixTrie128CreateRandom(b *testing.B) {
+	benchmarkCreateRandom(b, newTrie128Adapter(NewRadixTrie128()))
+}
@@ -2,25 +2,39 @@ package tree
 
 // Simple bitwise trie implementation.
 
+import (
+	"iter"
+	"math"
+)
+
 // Bitwise trie.
-type trie struct {
-	root trieNode
+type trie[V any] struct {
+	root trieNode[V]
+
+	// Number of keys in the trie.
+	size int
 }
 
 // Node in a bitwise trie.
-type trieNode struct {
-	value    interface{}
-	children [2]*trieNode
+type trieNode[V any] struct {
+	value    V
+	children [2]*trieNode[V]
 }
 
 // NewBinaryTrie creates an empty binary trie. Time complexity is
 // O(m), where m is the size of the bit string (64). This implementation does
 // not do any optimizations for special cases.
 func NewBinaryTrie() Trie {
-	return new(trie)
+	return NewBinaryTrieOf[interface{}]()
+}
+
+// NewBinaryTrieOf creates an empty binary trie with values of type V. See
+// NewBinaryTrie.
+func NewBinaryTrieOf[V any]() TrieOf[V] {
+	return new(trie[V])
 }
 
-func (tr *trie) Get(key uint64) (interface{}, bool) {
+func (tr *trie[V]) Get(key uint64) (V, bool) {
 	node := &tr.root
 
 	for i := uint(64); i > 0; i-- {
@@ -31,25 +45,26 @@ func (tr *trie) Get(key uint64) (interface{}, bool) {
 		}
 
 		if node == nil {
-			return nil, false
+			var value V
+			return value, false
 		}
 	}
 
 	return node.value, true
 }
 
-func (tr *trie) Set(key uint64, value interface{}) (interface{}, bool) {
+func (tr *trie[V]) Set(key uint64, value V) (V, bool) {
 	node := &tr.root
 
 	for i := uint(64); i > 1; i-- {
 		if key&(1<<(i-1)) == 0 {
 			if node.children[0] == nil {
-				node.children[0] = new(trieNode)
+				node.children[0] = new(trieNode[V])
 			}
 			node = node.children[0]
 		} else {
 			if node.children[1] == nil {
-				node.children[1] = new(trieNode)
+				node.children[1] = new(trieNode[V])
 			}
 			node = node.children[1]
 		}
@@ -57,8 +72,10 @@ func (tr *trie) Set(key uint64, value interface{}) (interface{}, bool) {
 
 	idx := key & 1
 	if node.children[idx] == nil {
-		node.children[idx] = &trieNode{value: value}
-		return nil, false
+		node.children[idx] = &trieNode[V]{value: value}
+		tr.size++
+		var origValue V
+		return origValue, false
 	} else {
 		origValue := node.children[idx].value
 		node.children[idx].value = value
@@ -66,10 +83,14 @@ func (tr *trie) Set(key uint64, value interface{}) (interface{}, bool) {
 	}
 }
 
-func (tr *trie) Del(key uint64) (interface{}, bool) {
+func (tr *trie[V]) Del(key uint64) (V, bool) {
+	// Remember the path so that nodes left empty can be pruned. path[d] is
+	// the node at depth d.
+	var path [64]*trieNode[V]
 	node := &tr.root
 
 	for i := uint(64); i > 1; i-- {
+		path[64-i] = node
 		if key&(1<<(i-1)) == 0 {
 			node = node.children[0]
 		} else {
@@ -77,16 +98,128 @@ func (tr *trie) Del(key uint64) (interface{}, bool) {
 		}
 
 		if node == nil {
-			return nil, false
+			var value V
+			return value, false
 		}
 	}
+	path[63] = node
 
 	idx := key & 1
 	if node.children[idx] == nil {
-		return nil, false
-	} else {
-		origValue := node.children[idx].value
-		node.children[idx] = nil
-		return origValue, true
+		var value V
+		return value, false
+	}
+	origValue := node.children[idx].value
+	node.children[idx] = nil
+	tr.size--
+
+	// Remove the nodes left without children so that walks do not visit
+	// them.
+	for d := 63; d > 0; d-- {
+		node = path[d]
+		if node.children[0] != nil || node.children[1] != nil {
+			break
+		}
+		path[d-1].children[(key>>(64-d))&1] = nil
+	}
+	return origValue, true
+}
+
+func (tr *trie[V]) Ascend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		tr.walk(0, math.MaxUint64, false, yield)
+	}
+}
+
+func (tr *trie[V]) Descend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		tr.walk(0, math.MaxUint64, true, yield)
+	}
+}
+
+func (tr *trie[V]) Range(lo, hi uint64) iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		if lo < hi {
+			tr.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (tr *trie[V]) Min() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).min()
+}
+
+func (tr *trie[V]) Max() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).max()
+}
+
+func (tr *trie[V]) Floor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).floor(key)
+}
+
+func (tr *trie[V]) Ceiling(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).ceiling(key)
+}
+
+func (tr *trie[V]) Predecessor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).predecessor(key)
+}
+
+func (tr *trie[V]) Successor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](tr.walk).successor(key)
+}
+
+func (tr *trie[V]) Len() int {
+	return tr.size
+}
+
+func (tr *trie[V]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	trieWalkFunc[uint64, V](tr.walk).prefix(prefix, bits, fn)
+}
+
+func (tr *trie[V]) CountPrefix(prefix uint64, bits uint) int {
+	return trieWalkFunc[uint64, V](tr.walk).countPrefix(prefix, bits)
+}
+
+func (tr *trie[V]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	return tr.root.walk(0, 0, lo, hi, reverse, func(key uint64, leaf *trieNode[V]) bool {
+		return yield(key, leaf.value)
+	})
+}
+
+// walk calls yield on every leaf at depth 64 underneath this node with a key
+// between lo and hi, inclusive, in ascending order of keys, or descending order
+// if reverse is true. The node is at the given depth and prefix contains the
+// bits along the path to it. Subtrees outside of the range are skipped. It
+// returns false if yield returned false.
+//
+// Tries with narrower keys start the walk at a greater depth, and tries with
+// wider keys walk from each leaf again for the next 64 bits.
+func (node *trieNode[V]) walk(prefix uint64, depth uint, lo, hi uint64, reverse bool, yield func(uint64, *trieNode[V]) bool) bool {
+	if depth == 64 {
+		return yield(prefix, node)
+	}
+
+	for i := 0; i < 2; i++ {
+		idx := i
+		if reverse {
+			idx = 1 - i
+		}
+		child := node.children[idx]
+		if child == nil {
+			continue
+		}
+
+		// The child's subtree contains the keys from first to last.
+		first := prefix | uint64(idx)<<(63-depth)
+		last := first | (1<<(63-depth) - 1)
+		if last < lo || first > hi {
+			continue
+		}
+
+		if !child.walk(first, depth+1, lo, hi, reverse, yield) {
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,182 @@
+package tree
+
+// Simple bitwise trie implementation with 128-bit keys.
+
+import (
+	"iter"
+	"math"
+)
+
+// Bitwise trie with 128-bit keys.
+type trie128[V any] struct {
+	root trieNode[V]
+
+	// Number of keys in the trie.
+	size int
+}
+
+// NewBinaryTrie128 creates an empty binary trie with Uint128 keys. Like
+// NewBinaryTrie, it has one level per bit, so time complexity is O(m), where m
+// is the size of the bit string (128).
+func NewBinaryTrie128() Trie128 {
+	return NewBinaryTrie128Of[interface{}]()
+}
+
+// NewBinaryTrie128Of creates an empty binary trie with Uint128 keys and values
+// of type V. See NewBinaryTrie128.
+func NewBinaryTrie128Of[V any]() Trie128Of[V] {
+	return new(trie128[V])
+}
+
+func (tr *trie128[V]) Get(key Uint128) (V, bool) {
+	node := tr.root.find128(key, 128, nil)
+	if node == nil {
+		var value V
+		return value, false
+	}
+	return node.value, true
+}
+
+func (tr *trie128[V]) Set(key Uint128, value V) (V, bool) {
+	node, created := tr.root.insert128(key, 128)
+	origValue := node.value
+	node.value = value
+	if created {
+		tr.size++
+		return origValue, false
+	}
+	return origValue, true
+}
+
+func (tr *trie128[V]) Del(key Uint128) (V, bool) {
+	var path [129]*trieNode[V]
+	node := tr.root.find128(key, 128, &path)
+	if node == nil {
+		var value V
+		return value, false
+	}
+	tr.size--
+	// The leaf has no children, so it is always removed.
+	trieNode128Prune(&path, key, 128, nil)
+	return node.value, true
+}
+
+// find128 returns the node at the given depth on the path to the given key
+// below this node, or nil if there is no such node. If path is not nil, path[d]
+// is set to the node at each depth d along the way.
+func (node *trieNode[V]) find128(key Uint128, depth uint, path *[129]*trieNode[V]) *trieNode[V] {
+	for i := uint(0); i < depth; i++ {
+		if path != nil {
+			path[i] = node
+		}
+		node = node.children[key.bit(i)]
+		if node == nil {
+			return nil
+		}
+	}
+	if path != nil {
+		path[depth] = node
+	}
+	return node
+}
+
+// insert128 returns the node at the given depth on the path to the given key
+// below this node, creating it and any missing nodes above it. It also returns
+// whether the node was created.
+func (node *trieNode[V]) insert128(key Uint128, depth uint) (*trieNode[V], bool) {
+	created := false
+	for i := uint(0); i < depth; i++ {
+		idx := key.bit(i)
+		if node.children[idx] == nil {
+			node.children[idx] = new(trieNode[V])
+			created = true
+		}
+		node = node.children[idx]
+	}
+	return node, created
+}
+
+// trieNode128Prune removes the nodes left without children at the end of a
+// path filled in by find128, starting from the given depth, so that walks do
+// not visit them. It stops at the first node with children or for which keep
+// returns true. keep may be nil if no node must be kept.
+func trieNode128Prune[V any](path *[129]*trieNode[V], key Uint128, depth uint, keep func(*trieNode[V]) bool) {
+	for d := depth; d > 0; d-- {
+		node := path[d]
+		if node.children[0] != nil || node.children[1] != nil || (keep != nil && keep(node)) {
+			break
+		}
+		path[d-1].children[key.bit(d-1)] = nil
+	}
+}
+
+func (tr *trie128[V]) Ascend() iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		tr.walk(Uint128{}, maxUint128, false, yield)
+	}
+}
+
+func (tr *trie128[V]) Descend() iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		tr.walk(Uint128{}, maxUint128, true, yield)
+	}
+}
+
+func (tr *trie128[V]) Range(lo, hi Uint128) iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		trieWalkFunc[Uint128, V](tr.walk).rangeSeq(lo, hi, yield)
+	}
+}
+
+func (tr *trie128[V]) Min() (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).min()
+}
+
+func (tr *trie128[V]) Max() (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).max()
+}
+
+func (tr *trie128[V]) Floor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).floor(key)
+}
+
+func (tr *trie128[V]) Ceiling(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).ceiling(key)
+}
+
+func (tr *trie128[V]) Predecessor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).predecessor(key)
+}
+
+func (tr *trie128[V]) Successor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](tr.walk).successor(key)
+}
+
+func (tr *trie128[V]) Len() int {
+	return tr.size
+}
+
+func (tr *trie128[V]) WalkPrefix(prefix Uint128, bits uint, fn func(Uint128, V) bool) {
+	trieWalkFunc[Uint128, V](tr.walk).prefix(prefix, bits, fn)
+}
+
+func (tr *trie128[V]) CountPrefix(prefix Uint128, bits uint) int {
+	return trieWalkFunc[Uint128, V](tr.walk).countPrefix(prefix, bits)
+}
+
+func (tr *trie128[V]) walk(lo, hi Uint128, reverse bool, yield func(Uint128, V) bool) bool {
+	// Walk the high halves of the keys, and then the low halves below each
+	// of them. Only the first and last high halves bound the low halves.
+	return tr.root.walk(0, 0, lo.Hi, hi.Hi, reverse, func(keyHi uint64, node *trieNode[V]) bool {
+		loLo, hiLo := uint64(0), uint64(math.MaxUint64)
+		if keyHi == lo.Hi {
+			loLo = lo.Lo
+		}
+		if keyHi == hi.Hi {
+			hiLo = hi.Lo
+		}
+		return node.walk(0, 0, loLo, hiLo, reverse, func(keyLo uint64, leaf *trieNode[V]) bool {
+			return yield(Uint128{keyHi, keyLo}, leaf.value)
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package tree
+
+// Simple bitwise trie implementation with 32-bit keys.
+
+import (
+	"iter"
+	"math"
+)
+
+// Bitwise trie with 32-bit keys.
+type trie32[V any] struct {
+	root trieNode[V]
+
+	// Number of keys in the trie.
+	size int
+}
+
+// NewBinaryTrie32 creates an empty binary trie with uint32 keys. Like
+// NewBinaryTrie, it has one level per bit, but there are only 32 of them.
+func NewBinaryTrie32() Trie32 {
+	return NewBinaryTrie32Of[interface{}]()
+}
+
+// NewBinaryTrie32Of creates an empty binary trie with uint32 keys and values of
+// type V. See NewBinaryTrie32.
+func NewBinaryTrie32Of[V any]() Trie32Of[V] {
+	return new(trie32[V])
+}
+
+func (tr *trie32[V]) Get(key uint32) (V, bool) {
+	node := &tr.root
+
+	for i := uint(32); i > 0; i-- {
+		node = node.children[(key>>(i-1))&1]
+		if node == nil {
+			var value V
+			return value, false
+		}
+	}
+
+	return node.value, true
+}
+
+func (tr *trie32[V]) Set(key uint32, value V) (V, bool) {
+	node := &tr.root
+
+	for i := uint(32); i > 1; i-- {
+		idx := (key >> (i - 1)) & 1
+		if node.children[idx] == nil {
+			node.children[idx] = new(trieNode[V])
+		}
+		node = node.children[idx]
+	}
+
+	idx := key & 1
+	if node.children[idx] == nil {
+		node.children[idx] = &trieNode[V]{value: value}
+		tr.size++
+		var origValue V
+		return origValue, false
+	} else {
+		origValue := node.children[idx].value
+		node.children[idx].value = value
+		return origValue, true
+	}
+}
+
+func (tr *trie32[V]) Del(key uint32) (V, bool) {
+	// Remember the path so that nodes left empty can be pruned. path[d] is
+	// the node at depth d.
+	var path [32]*trieNode[V]
+	node := &tr.root
+
+	for i := uint(32); i > 1; i-- {
+		path[32-i] = node
+		node = node.children[(key>>(i-1))&1]
+		if node == nil {
+			var value V
+			return value, false
+		}
+	}
+	path[31] = node
+
+	idx := key & 1
+	if node.children[idx] == nil {
+		var value V
+		return value, false
+	}
+	origValue := node.children[idx].value
+	node.children[idx] = nil
+	tr.size--
+
+	// Remove the nodes left without children so that walks do not visit
+	// them.
+	for d := 31; d > 0; d-- {
+		node = path[d]
+		if node.children[0] != nil || node.children[1] != nil {
+			break
+		}
+		path[d-1].children[(key>>(32-d))&1] = nil
+	}
+	return origValue, true
+}
+
+func (tr *trie32[V]) Ascend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		tr.walk(0, math.MaxUint32, false, yield)
+	}
+}
+
+func (tr *trie32[V]) Descend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		tr.walk(0, math.MaxUint32, true, yield)
+	}
+}
+
+func (tr *trie32[V]) Range(lo, hi uint32) iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		if lo < hi {
+			tr.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (tr *trie32[V]) Min() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).min()
+}
+
+func (tr *trie32[V]) Max() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).max()
+}
+
+func (tr *trie32[V]) Floor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).floor(key)
+}
+
+func (tr *trie32[V]) Ceiling(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).ceiling(key)
+}
+
+func (tr *trie32[V]) Predecessor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).predecessor(key)
+}
+
+func (tr *trie32[V]) Successor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](tr.walk).successor(key)
+}
+
+func (tr *trie32[V]) Len() int {
+	return tr.size
+}
+
+func (tr *trie32[V]) WalkPrefix(prefix uint32, bits uint, fn func(uint32, V) bool) {
+	trieWalkFunc[uint32, V](tr.walk).prefix(prefix, bits, fn)
+}
+
+func (tr *trie32[V]) CountPrefix(prefix uint32, bits uint) int {
+	return trieWalkFunc[uint32, V](tr.walk).countPrefix(prefix, bits)
+}
+
+func (tr *trie32[V]) walk(lo, hi uint32, reverse bool, yield func(uint32, V) bool) bool {
+	return tr.root.walk32(lo, hi, reverse, yield)
+}
+
+// walk32 walks a trie with 32-bit keys rooted at this node. The keys are
+// zero-extended, so the root is at depth 32. See walk.
+func (node *trieNode[V]) walk32(lo, hi uint32, reverse bool, yield func(uint32, V) bool) bool {
+	return node.walk(0, 32, uint64(lo), uint64(hi), reverse, func(key uint64, leaf *trieNode[V]) bool {
+		return yield(uint32(key), leaf.value)
+	})
+}
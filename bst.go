@@ -2,38 +2,95 @@ package tree
 
 // Binary search tree implementation.
 
+import (
+	"cmp"
+	"iter"
+)
+
 // Binary search tree.
-type binarySearchTree struct {
+type binarySearchTree[K, V any] struct {
 	// Root of the tree.
-	root *bstNode
+	root *bstNode[K, V]
+
+	// Comparison function for keys.
+	compare func(K, K) int
 }
 
-// Node in a binary search tree.
-type bstNode struct {
-	key                 Key
-	value               interface{}
-	parent, left, right *bstNode
+// Node in a binary search tree. Keys greater than the node's key are in its
+// left subtree and keys less than the node's key are in its right subtree.
+type bstNode[K, V any] struct {
+	key                 K
+	value               V
+	parent, left, right *bstNode[K, V]
+
+	// Number of nodes in the subtree rooted at this node.
+	size int
+
+	// Extra information used by self-balancing trees built on top of the
+	// binary search tree.
+	balance int
 }
 
 // NewBST creates an empty binary search tree. The binary search tree is not
 // self-balancing, so in the worst case all operations are O(n), but the
-// average complexity is O(log n).
+// average complexity is O(log n). The returned tree is an OrderStatisticTree;
+// see NewOrderStatisticBST.
 func NewBST() Tree {
-	return new(binarySearchTree)
+	return NewBSTOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewBSTOf creates an empty binary search tree ordered by the natural order of
+// its keys. See NewBST.
+func NewBSTOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewBSTOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewBSTOfFunc creates an empty binary search tree ordered by the given
+// comparison function, which returns a negative number, zero, or a positive
+// number when its first argument is less than, equal to, or greater than its
+// second argument, respectively. See NewBST.
+func NewBSTOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	return NewOrderStatisticBSTOfFunc[K, V](compare)
+}
+
+// NewBSTFunc creates an empty binary search tree ordered by the given
+// comparison function rather than by the Key interface, so keys of any type can
+// be used without wrapping them. See NewBST and NewBSTOfFunc.
+func NewBSTFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewBSTOfFunc[interface{}, interface{}](compare)
+}
+
+// NewOrderStatisticBST creates an empty binary search tree like NewBST but
+// returns it as an OrderStatisticTree.
+func NewOrderStatisticBST() OrderStatisticTree {
+	return NewOrderStatisticBSTOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewOrderStatisticBSTOf creates an empty binary search tree like NewBSTOf but
+// returns it as an OrderStatisticTreeOf[K, V].
+func NewOrderStatisticBSTOf[K cmp.Ordered, V any]() OrderStatisticTreeOf[K, V] {
+	return NewOrderStatisticBSTOfFunc[K, V](cmp.Compare[K])
 }
 
-func (bst *binarySearchTree) Get(key Key) (interface{}, bool) {
+// NewOrderStatisticBSTOfFunc creates an empty binary search tree like
+// NewBSTOfFunc but returns it as an OrderStatisticTreeOf[K, V].
+func NewOrderStatisticBSTOfFunc[K, V any](compare func(K, K) int) OrderStatisticTreeOf[K, V] {
+	return &binarySearchTree[K, V]{compare: compare}
+}
+
+func (bst *binarySearchTree[K, V]) Get(key K) (V, bool) {
 	node := bst.get(key)
 
 	if node == nil {
-		return nil, false
+		var value V
+		return value, false
 	} else {
 		return node.value, true
 	}
 }
 
 // get finds the node with the given key.
-func (bst *binarySearchTree) get(key Key) *bstNode {
+func (bst *binarySearchTree[K, V]) get(key K) *bstNode[K, V] {
 	node := bst.root
 
 	// Iterate down the tree.
@@ -43,7 +100,7 @@ func (bst *binarySearchTree) get(key Key) *bstNode {
 			return nil
 		}
 
-		cmp := node.key.CompareTo(key)
+		cmp := bst.compare(node.key, key)
 		if cmp < 0 {
 			node = node.left
 		} else if cmp > 0 {
@@ -55,7 +112,7 @@ func (bst *binarySearchTree) get(key Key) *bstNode {
 	}
 }
 
-func (bst *binarySearchTree) Set(key Key, value interface{}) (interface{}, bool) {
+func (bst *binarySearchTree[K, V]) Set(key K, value V) (V, bool) {
 	node, exists := bst.add(key)
 
 	if exists {
@@ -64,34 +121,37 @@ func (bst *binarySearchTree) Set(key Key, value interface{}) (interface{}, bool)
 		return origValue, true
 	} else {
 		node.value = value
-		return nil, false
+		var origValue V
+		return origValue, false
 	}
 }
 
 // add returns the node containing the given key or creates one and returns it.
-func (bst *binarySearchTree) add(key Key) (*bstNode, bool) {
+func (bst *binarySearchTree[K, V]) add(key K) (*bstNode[K, V], bool) {
 	node := bst.root
 
 	// If the root is nil, then this is the first node and therefore the new
 	// root.
 	if node == nil {
-		bst.root = &bstNode{key, nil, nil, nil, nil}
+		bst.root = &bstNode[K, V]{key: key, size: 1}
 		return bst.root, false
 	}
 
 	// Iterate down the tree.
 	for {
-		cmp := node.key.CompareTo(key)
+		cmp := bst.compare(node.key, key)
 		if cmp < 0 {
 			if node.left == nil {
-				node.left = &bstNode{key, nil, node, nil, nil}
+				node.left = &bstNode[K, V]{key: key, parent: node, size: 1}
+				node.resizeUp()
 				return node.left, false
 			} else {
 				node = node.left
 			}
 		} else if cmp > 0 {
 			if node.right == nil {
-				node.right = &bstNode{key, nil, node, nil, nil}
+				node.right = &bstNode[K, V]{key: key, parent: node, size: 1}
+				node.resizeUp()
 				return node.right, false
 			} else {
 				node = node.right
@@ -103,24 +163,32 @@ func (bst *binarySearchTree) add(key Key) (*bstNode, bool) {
 	}
 }
 
-func (bst *binarySearchTree) Del(key Key) (interface{}, bool) {
+func (bst *binarySearchTree[K, V]) Del(key K) (V, bool) {
 	node := bst.del(key)
 
 	if node == nil {
-		return nil, false
+		var value V
+		return value, false
 	} else {
 		return node.value, true
 	}
 }
 
 // del removes the node with the given key and returns it.
-func (bst *binarySearchTree) del(key Key) *bstNode {
+func (bst *binarySearchTree[K, V]) del(key K) *bstNode[K, V] {
 	node := bst.get(key)
-	if node == nil {
-		return nil
+	if node != nil {
+		bst.remove(node)
 	}
+	return node
+}
 
-	var replacement *bstNode
+// remove removes the given node from the tree. It returns the lowest node whose
+// subtree changed, or nil if there is none.
+func (bst *binarySearchTree[K, V]) remove(node *bstNode[K, V]) *bstNode[K, V] {
+	// Lowest node whose subtree changed.
+	var changed *bstNode[K, V]
+	var replacement *bstNode[K, V]
 
 	if node.left != nil && node.right != nil {
 		// Two children.
@@ -132,6 +200,11 @@ func (bst *binarySearchTree) del(key Key) *bstNode {
 			successor = successor.left
 		}
 
+		changed = successor.parent
+		if changed == node {
+			changed = successor
+		}
+
 		// Remove it from the tree.
 		if successor == successor.parent.left {
 			successor.parent.left = successor.right
@@ -166,8 +239,21 @@ func (bst *binarySearchTree) del(key Key) *bstNode {
 		replacement = node.right
 	}
 
+	if changed == nil {
+		changed = node.parent
+	}
+
 	// No children and common fall-through code.
 
+	bst.replace(node, replacement)
+
+	changed.resizeUp()
+	return changed
+}
+
+// replace puts the given replacement, which may be nil, in the place of the
+// given node in the tree.
+func (bst *binarySearchTree[K, V]) replace(node, replacement *bstNode[K, V]) {
 	if node.parent == nil {
 		bst.root = replacement
 	} else if node == node.parent.left {
@@ -178,12 +264,262 @@ func (bst *binarySearchTree) del(key Key) *bstNode {
 	if replacement != nil {
 		replacement.parent = node.parent
 	}
+}
+
+func (bst *binarySearchTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := bst.min(); node != nil; node = node.next() {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+func (bst *binarySearchTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := bst.max(); node != nil; node = node.prev() {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+func (bst *binarySearchTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		bst.ascendTo(bst.nearest(lo, true, true), hi, yield)
+	}
+}
+
+func (bst *binarySearchTree[K, V]) Min() (K, V, bool) {
+	return bst.min().entry()
+}
+
+func (bst *binarySearchTree[K, V]) Max() (K, V, bool) {
+	return bst.max().entry()
+}
+
+func (bst *binarySearchTree[K, V]) Floor(key K) (K, V, bool) {
+	return bst.nearest(key, false, true).entry()
+}
+
+func (bst *binarySearchTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return bst.nearest(key, true, true).entry()
+}
+
+func (bst *binarySearchTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return bst.nearest(key, false, false).entry()
+}
+
+func (bst *binarySearchTree[K, V]) Successor(key K) (K, V, bool) {
+	return bst.nearest(key, true, false).entry()
+}
+
+func (bst *binarySearchTree[K, V]) Len() int {
+	return bst.root.subtreeSize()
+}
+
+func (bst *binarySearchTree[K, V]) Rank(key K) int {
+	rank, _ := bst.rank(key)
+	return rank
+}
+
+func (bst *binarySearchTree[K, V]) Select(i int) (K, V, bool) {
+	return bst.nth(i).entry()
+}
+
+// rank finds the number of keys less than the given key. It also returns the
+// last node visited.
+func (bst *binarySearchTree[K, V]) rank(key K) (int, *bstNode[K, V]) {
+	rank := 0
+	var last *bstNode[K, V]
+	node := bst.root
+
+	for node != nil {
+		last = node
+		cmp := bst.compare(node.key, key)
+		if cmp < 0 {
+			// This node and its right subtree are less than the key.
+			rank += 1 + node.right.subtreeSize()
+			node = node.left
+		} else if cmp > 0 {
+			node = node.right
+		} else {
+			return rank + node.right.subtreeSize(), node
+		}
+	}
+	return rank, last
+}
 
+// nth finds the node with the given rank.
+func (bst *binarySearchTree[K, V]) nth(i int) *bstNode[K, V] {
+	if i < 0 || i >= bst.root.subtreeSize() {
+		return nil
+	}
+
+	node := bst.root
+	for {
+		rank := node.right.subtreeSize()
+		if i < rank {
+			node = node.right
+		} else if i > rank {
+			i -= rank + 1
+			node = node.left
+		} else {
+			return node
+		}
+	}
+}
+
+// min finds the node with the smallest key in the tree.
+func (bst *binarySearchTree[K, V]) min() *bstNode[K, V] {
+	if bst.root == nil {
+		return nil
+	}
+	return bst.root.min()
+}
+
+// max finds the node with the largest key in the tree.
+func (bst *binarySearchTree[K, V]) max() *bstNode[K, V] {
+	if bst.root == nil {
+		return nil
+	}
+	return bst.root.max()
+}
+
+// nearest finds the node closest to the given key in one direction. If above
+// is true, it finds the node with the smallest key greater than the given key;
+// otherwise, it finds the node with the largest key less than the given key. If
+// inclusive is true and the key is in the tree, its node is returned instead.
+func (bst *binarySearchTree[K, V]) nearest(key K, above, inclusive bool) *bstNode[K, V] {
+	var best *bstNode[K, V]
+	node := bst.root
+
+	for node != nil {
+		cmp := bst.compare(node.key, key)
+		if cmp < 0 {
+			// This node is a candidate if we're looking below, but there may
+			// be a larger one.
+			if !above {
+				best = node
+			}
+			node = node.left
+		} else if cmp > 0 {
+			// This node is a candidate if we're looking above, but there may
+			// be a smaller one.
+			if above {
+				best = node
+			}
+			node = node.right
+		} else if inclusive {
+			return node
+		} else if above {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return best
+}
+
+// entry returns the key and value of this node and true, or false if the node
+// is nil.
+func (node *bstNode[K, V]) entry() (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	} else {
+		return node.key, node.value, true
+	}
+}
+
+// ascendTo calls yield on the given node and the nodes following it in
+// ascending order of keys until it reaches a key greater than or equal to hi.
+// The node may be nil.
+func (bst *binarySearchTree[K, V]) ascendTo(node *bstNode[K, V], hi K, yield func(K, V) bool) {
+	for ; node != nil && bst.compare(node.key, hi) < 0; node = node.next() {
+		if !yield(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// min returns the node with the smallest key in the subtree rooted at this
+// node.
+func (node *bstNode[K, V]) min() *bstNode[K, V] {
+	for node.right != nil {
+		node = node.right
+	}
 	return node
 }
 
-func (bst *binarySearchTree) rotateLeft(node *bstNode) {
+// max returns the node with the largest key in the subtree rooted at this
+// node.
+func (node *bstNode[K, V]) max() *bstNode[K, V] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// next returns the node with the smallest key greater than this node's key,
+// or nil if there is none.
+func (node *bstNode[K, V]) next() *bstNode[K, V] {
+	if node.left != nil {
+		return node.left.min()
+	}
+
+	// Walk up until we come from a right subtree; that ancestor is the next
+	// node.
+	for node.parent != nil && node == node.parent.left {
+		node = node.parent
+	}
+	return node.parent
+}
+
+// prev returns the node with the largest key less than this node's key, or
+// nil if there is none.
+func (node *bstNode[K, V]) prev() *bstNode[K, V] {
+	if node.right != nil {
+		return node.right.max()
+	}
+
+	// Walk up until we come from a left subtree; that ancestor is the
+	// previous node.
+	for node.parent != nil && node == node.parent.right {
+		node = node.parent
+	}
+	return node.parent
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at this node,
+// which may be nil.
+func (node *bstNode[K, V]) subtreeSize() int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// resize recomputes the size of this node's subtree from its children.
+func (node *bstNode[K, V]) resize() {
+	node.size = 1 + node.left.subtreeSize() + node.right.subtreeSize()
+}
+
+// resizeUp recomputes the subtree sizes of this node, which may be nil, and
+// all of its ancestors.
+func (node *bstNode[K, V]) resizeUp() {
+	for ; node != nil; node = node.parent {
+		node.resize()
+	}
+}
+
+func (bst *binarySearchTree[K, V]) rotateLeft(node *bstNode[K, V]) {
 	right := node.right
+	right.size = node.size
 
 	node.right = right.left
 	if node.right != nil {
@@ -201,10 +537,12 @@ func (bst *binarySearchTree) rotateLeft(node *bstNode) {
 	right.parent = node.parent
 	right.left = node
 	node.parent = right
+	node.resize()
 }
 
-func (bst *binarySearchTree) rotateRight(node *bstNode) {
+func (bst *binarySearchTree[K, V]) rotateRight(node *bstNode[K, V]) {
 	left := node.left
+	left.size = node.size
 
 	node.left = left.right
 	if node.left != nil {
@@ -222,4 +560,5 @@ func (bst *binarySearchTree) rotateRight(node *bstNode) {
 	left.parent = node.parent
 	left.right = node
 	node.parent = left
+	node.resize()
 }
@@ -0,0 +1,465 @@
+package tree
+
+// B-tree implementation.
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// B-tree.
+type bTree[K, V any] struct {
+	// Minimum degree of the tree. Every node other than the root has between
+	// degree - 1 and 2 * degree - 1 keys.
+	degree int
+
+	// Root of the tree.
+	root *bTreeNode[K, V]
+
+	// Number of keys in the tree.
+	size int
+
+	// Comparison function for keys.
+	compare func(K, K) int
+}
+
+// Node in a B-tree. The keys are sorted in ascending order, and the keys in
+// children[i] are between keys[i - 1] and keys[i].
+type bTreeNode[K, V any] struct {
+	keys   []K
+	values []V
+
+	// Children of the node, or nil if it is a leaf.
+	children []*bTreeNode[K, V]
+}
+
+// NewBTree creates an empty B-tree with the given minimum degree, which must
+// be at least 2. A B-tree stores between degree - 1 and 2 * degree - 1 sorted
+// keys in each node, which makes it shallow and cache-friendly. All operations
+// are O(log n) in the worst case.
+func NewBTree(degree int) Tree {
+	return NewBTreeOfFunc[Key, interface{}](degree, compareKeys)
+}
+
+// NewBTreeOf creates an empty B-tree with the given minimum degree ordered by
+// the natural order of its keys. See NewBTree.
+func NewBTreeOf[K cmp.Ordered, V any](degree int) TreeOf[K, V] {
+	return NewBTreeOfFunc[K, V](degree, cmp.Compare[K])
+}
+
+// NewBTreeOfFunc creates an empty B-tree with the given minimum degree ordered
+// by the given comparison function. See NewBTree and NewBSTOfFunc.
+func NewBTreeOfFunc[K, V any](degree int, compare func(K, K) int) TreeOf[K, V] {
+	if degree < 2 {
+		panic("invalid B-tree degree")
+	}
+	return &bTree[K, V]{degree: degree, compare: compare}
+}
+
+// NewBTreeFunc creates an empty B-tree with the given minimum degree ordered by
+// the given comparison function. See NewBTree and NewBSTFunc.
+func NewBTreeFunc(degree int, compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewBTreeOfFunc[interface{}, interface{}](degree, compare)
+}
+
+func (bt *bTree[K, V]) Get(key K) (V, bool) {
+	node := bt.root
+
+	for node != nil {
+		i, found := bt.find(node, key)
+		if found {
+			return node.values[i], true
+		}
+		node = node.child(i)
+	}
+
+	var value V
+	return value, false
+}
+
+func (bt *bTree[K, V]) Set(key K, value V) (V, bool) {
+	if bt.root == nil {
+		bt.root = &bTreeNode[K, V]{keys: []K{key}, values: []V{value}}
+		bt.size++
+		var origValue V
+		return origValue, false
+	}
+
+	// Split full nodes on the way down so that there is always room to insert
+	// into a leaf or to move up a median from a child.
+	if bt.root.full(bt.degree) {
+		bt.root = &bTreeNode[K, V]{children: []*bTreeNode[K, V]{bt.root}}
+		bt.root.splitChild(0, bt.degree)
+	}
+
+	node := bt.root
+	for {
+		i, found := bt.find(node, key)
+		if found {
+			origValue := node.values[i]
+			node.keys[i] = key
+			node.values[i] = value
+			return origValue, true
+		}
+
+		if node.children == nil {
+			node.keys = slices.Insert(node.keys, i, key)
+			node.values = slices.Insert(node.values, i, value)
+			bt.size++
+			var origValue V
+			return origValue, false
+		}
+
+		if node.children[i].full(bt.degree) {
+			node.splitChild(i, bt.degree)
+			// The median moved up into this node, so the key may be it or in
+			// the new right half.
+			continue
+		}
+		node = node.children[i]
+	}
+}
+
+func (bt *bTree[K, V]) Del(key K) (V, bool) {
+	if bt.root == nil {
+		var value V
+		return value, false
+	}
+
+	value, ok := bt.del(bt.root, key)
+
+	// The root may have been emptied by a merge or by removing its last key.
+	if len(bt.root.keys) == 0 {
+		if bt.root.children == nil {
+			bt.root = nil
+		} else {
+			bt.root = bt.root.children[0]
+		}
+	}
+
+	if ok {
+		bt.size--
+	}
+	return value, ok
+}
+
+func (bt *bTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.ascend(bt.root, nil, nil, yield)
+		}
+	}
+}
+
+func (bt *bTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.root.descend(yield)
+		}
+	}
+}
+
+func (bt *bTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.ascend(bt.root, &lo, &hi, yield)
+		}
+	}
+}
+
+func (bt *bTree[K, V]) Min() (K, V, bool) {
+	node := bt.root
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	for node.children != nil {
+		node = node.children[0]
+	}
+	return node.keys[0], node.values[0], true
+}
+
+func (bt *bTree[K, V]) Max() (K, V, bool) {
+	node := bt.root
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	for node.children != nil {
+		node = node.children[len(node.children)-1]
+	}
+	return node.keys[len(node.keys)-1], node.values[len(node.values)-1], true
+}
+
+func (bt *bTree[K, V]) Floor(key K) (K, V, bool) {
+	return bt.nearest(key, false, true)
+}
+
+func (bt *bTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return bt.nearest(key, true, true)
+}
+
+func (bt *bTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return bt.nearest(key, false, false)
+}
+
+func (bt *bTree[K, V]) Successor(key K) (K, V, bool) {
+	return bt.nearest(key, true, false)
+}
+
+func (bt *bTree[K, V]) Len() int {
+	return bt.size
+}
+
+// nearest finds the entry closest to the given key in one direction. If above
+// is true, it finds the smallest key greater than the given key; otherwise, it
+// finds the largest key less than the given key. If inclusive is true and the
+// key is in the tree, its entry is returned instead.
+func (bt *bTree[K, V]) nearest(key K, above, inclusive bool) (K, V, bool) {
+	var best *bTreeNode[K, V]
+	var bestIndex int
+	node := bt.root
+
+	for node != nil {
+		i, found := bt.find(node, key)
+		if found && inclusive {
+			return node.keys[i], node.values[i], true
+		}
+
+		if above {
+			// Skip over an equal key.
+			if found {
+				i++
+			}
+			if i < len(node.keys) {
+				best, bestIndex = node, i
+			}
+		} else if i > 0 {
+			best, bestIndex = node, i-1
+		}
+		node = node.child(i)
+	}
+
+	if best == nil {
+		var key K
+		var value V
+		return key, value, false
+	} else {
+		return best.keys[bestIndex], best.values[bestIndex], true
+	}
+}
+
+// find returns the index of the first key in the given node greater than or
+// equal to the given key and whether it is equal.
+func (bt *bTree[K, V]) find(node *bTreeNode[K, V], key K) (int, bool) {
+	i := sort.Search(len(node.keys), func(i int) bool {
+		return bt.compare(node.keys[i], key) >= 0
+	})
+	return i, i < len(node.keys) && bt.compare(node.keys[i], key) == 0
+}
+
+// child returns the child at the given index, or nil if the node is a leaf.
+func (node *bTreeNode[K, V]) child(i int) *bTreeNode[K, V] {
+	if node.children == nil {
+		return nil
+	} else {
+		return node.children[i]
+	}
+}
+
+// full returns whether the node has the maximum number of keys.
+func (node *bTreeNode[K, V]) full(degree int) bool {
+	return len(node.keys) == 2*degree-1
+}
+
+// splitChild splits the full child at the given index into two nodes with
+// degree - 1 keys each and moves the median key up into this node.
+func (node *bTreeNode[K, V]) splitChild(i int, degree int) {
+	child := node.children[i]
+
+	right := &bTreeNode[K, V]{
+		keys:   slices.Clone(child.keys[degree:]),
+		values: slices.Clone(child.values[degree:]),
+	}
+	if child.children != nil {
+		right.children = slices.Clone(child.children[degree:])
+	}
+
+	node.keys = slices.Insert(node.keys, i, child.keys[degree-1])
+	node.values = slices.Insert(node.values, i, child.values[degree-1])
+	node.children = slices.Insert(node.children, i+1, right)
+
+	child.keys = slices.Delete(child.keys, degree-1, len(child.keys))
+	child.values = slices.Delete(child.values, degree-1, len(child.values))
+	if child.children != nil {
+		child.children = slices.Delete(child.children, degree, len(child.children))
+	}
+}
+
+// del removes the given key from the subtree rooted at the given node, which
+// must have at least degree keys unless it is the root, and returns its value.
+func (bt *bTree[K, V]) del(node *bTreeNode[K, V], key K) (V, bool) {
+	degree := bt.degree
+	i, found := bt.find(node, key)
+
+	if node.children == nil {
+		if !found {
+			var value V
+			return value, false
+		}
+		value := node.values[i]
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.values = slices.Delete(node.values, i, i+1)
+		return value, true
+	}
+
+	if !found {
+		// Make sure that the child has a key to spare before descending.
+		i = node.fill(i, degree)
+		return bt.del(node.children[i], key)
+	}
+
+	value := node.values[i]
+	if len(node.children[i].keys) >= degree {
+		// Replace the key with its predecessor.
+		pred := node.children[i]
+		for pred.children != nil {
+			pred = pred.children[len(pred.children)-1]
+		}
+		predKey := pred.keys[len(pred.keys)-1]
+		predValue, _ := bt.del(node.children[i], predKey)
+		node.keys[i], node.values[i] = predKey, predValue
+	} else if len(node.children[i+1].keys) >= degree {
+		// Replace the key with its successor.
+		succ := node.children[i+1]
+		for succ.children != nil {
+			succ = succ.children[0]
+		}
+		succKey := succ.keys[0]
+		succValue, _ := bt.del(node.children[i+1], succKey)
+		node.keys[i], node.values[i] = succKey, succValue
+	} else {
+		// Both neighboring children are minimal, so merge them around the key
+		// and remove it from the merged child.
+		node.merge(i)
+		bt.del(node.children[i], key)
+	}
+	return value, true
+}
+
+// fill makes sure that the child at the given index has at least degree keys
+// by borrowing a key from a sibling or merging it with a sibling. It returns
+// the new index of the child.
+func (node *bTreeNode[K, V]) fill(i int, degree int) int {
+	child := node.children[i]
+	if len(child.keys) >= degree {
+		return i
+	}
+
+	if i > 0 && len(node.children[i-1].keys) >= degree {
+		// Borrow the separator from this node and replace it with the last
+		// key of the left sibling.
+		left := node.children[i-1]
+		last := len(left.keys) - 1
+		child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+		child.values = slices.Insert(child.values, 0, node.values[i-1])
+		node.keys[i-1], node.values[i-1] = left.keys[last], left.values[last]
+		left.keys = slices.Delete(left.keys, last, last+1)
+		left.values = slices.Delete(left.values, last, last+1)
+		if child.children != nil {
+			child.children = slices.Insert(child.children, 0, left.children[last+1])
+			left.children = slices.Delete(left.children, last+1, last+2)
+		}
+		return i
+	}
+
+	if i < len(node.keys) && len(node.children[i+1].keys) >= degree {
+		// Borrow the separator from this node and replace it with the first
+		// key of the right sibling.
+		right := node.children[i+1]
+		child.keys = append(child.keys, node.keys[i])
+		child.values = append(child.values, node.values[i])
+		node.keys[i], node.values[i] = right.keys[0], right.values[0]
+		right.keys = slices.Delete(right.keys, 0, 1)
+		right.values = slices.Delete(right.values, 0, 1)
+		if child.children != nil {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return i
+	}
+
+	if i < len(node.keys) {
+		node.merge(i)
+		return i
+	} else {
+		node.merge(i - 1)
+		return i - 1
+	}
+}
+
+// merge merges the child at index i + 1 and the separating key at index i into
+// the child at index i.
+func (node *bTreeNode[K, V]) merge(i int) {
+	left := node.children[i]
+	right := node.children[i+1]
+
+	left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+	left.values = append(append(left.values, node.values[i]), right.values...)
+	if left.children != nil {
+		left.children = append(left.children, right.children...)
+	}
+
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.values = slices.Delete(node.values, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// ascend calls yield on the entries in the subtree rooted at the given node
+// with keys greater than or equal to lo and less than hi in ascending order. A
+// nil bound is unbounded. It returns false if iteration should stop, either
+// because yield returned false or because a key reached hi.
+func (bt *bTree[K, V]) ascend(node *bTreeNode[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = bt.find(node, *lo)
+	}
+
+	for ; i <= len(node.keys); i++ {
+		if node.children != nil {
+			if !bt.ascend(node.children[i], lo, hi, yield) {
+				return false
+			}
+			// Only the first child visited can contain keys less than lo.
+			lo = nil
+		}
+		if i == len(node.keys) {
+			break
+		}
+		if hi != nil && bt.compare(node.keys[i], *hi) >= 0 {
+			return false
+		}
+		if !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend calls yield on the entries in the subtree rooted at this node in
+// descending order. It returns false if yield returned false.
+func (node *bTreeNode[K, V]) descend(yield func(K, V) bool) bool {
+	for i := len(node.keys); i >= 0; i-- {
+		if node.children != nil && !node.children[i].descend(yield) {
+			return false
+		}
+		if i > 0 && !yield(node.keys[i-1], node.values[i-1]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package tree
+
+import (
+	"testing"
+)
+
+// checkBTree checks the invariants of a B-tree subtree whose keys must be
+// between lo and hi (exclusive, nil for unbounded) and returns its height.
+func checkBTree(t *testing.T, node *bTreeNode[Key, interface{}], degree int, root bool, lo, hi Key) int {
+	if !root && len(node.keys) < degree-1 {
+		t.Fatalf("node has %v keys, expected at least %v\n", len(node.keys), degree-1)
+	}
+	if len(node.keys) > 2*degree-1 {
+		t.Fatalf("node has %v keys, expected at most %v\n", len(node.keys), 2*degree-1)
+	}
+	if len(node.values) != len(node.keys) {
+		t.Fatalf("node has %v keys but %v values\n", len(node.keys), len(node.values))
+	}
+	for i, key := range node.keys {
+		if (lo != nil && key.CompareTo(lo) <= 0) || (i > 0 && key.CompareTo(node.keys[i-1]) <= 0) {
+			t.Fatalf("key %v is out of order\n", key)
+		}
+	}
+	if last := len(node.keys) - 1; hi != nil && node.keys[last].CompareTo(hi) >= 0 {
+		t.Fatalf("key %v is out of order\n", node.keys[last])
+	}
+
+	if node.children == nil {
+		return 0
+	}
+	if len(node.children) != len(node.keys)+1 {
+		t.Fatalf("node has %v keys but %v children\n", len(node.keys), len(node.children))
+	}
+	height := -1
+	for i, child := range node.children {
+		clo, chi := lo, hi
+		if i > 0 {
+			clo = node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			chi = node.keys[i]
+		}
+		h := checkBTree(t, child, degree, false, clo, chi)
+		if height >= 0 && h != height {
+			t.Fatalf("leaves at different depths\n")
+		}
+		height = h
+	}
+	return height + 1
+}
+
+func TestBTreeInvariants(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		bt := NewBTree(degree).(*bTree[Key, interface{}])
+		for _, v := range testRand.Perm(NUM_NODES) {
+			bt.Set(Uint64Key(v), v)
+		}
+		checkBTree(t, bt.root, degree, true, nil, nil)
+
+		for i, v := range testRand.Perm(NUM_NODES) {
+			bt.Del(Uint64Key(v))
+			if i%100 == 0 && bt.root != nil {
+				checkBTree(t, bt.root, degree, true, nil, nil)
+			}
+		}
+		if bt.root != nil {
+			t.Errorf("root is not nil after deleting all keys\n")
+		}
+	}
+}
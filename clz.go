@@ -20,3 +20,22 @@ func clz_g(x uint64) int {
 
 	return 64 - int(x)
 }
+
+func clz32(x uint32) (n int)
+
+func clz32_g(x uint32) int {
+	x |= (x >> 1)
+	x |= (x >> 2)
+	x |= (x >> 4)
+	x |= (x >> 8)
+	x |= (x >> 16)
+
+	x -= (x >> 1) & 0x55555555
+	x = (x & 0x33333333) + ((x >> 2) & 0x33333333)
+	x = (x + (x >> 4)) & 0x0f0f0f0f
+	x += x >> 8
+	x += x >> 16
+	x &= 0x3f
+
+	return 32 - int(x)
+}
@@ -4,3 +4,10 @@ TEXT ·clz(SB),4,$0-16
 	XORQ $63, AX
 	MOVQ AX, n+8(FP)
 	RET
+
+// func clz32(x uint32) (n int)
+TEXT ·clz32(SB),4,$0-16
+	BSRL x+0(FP), AX
+	XORL $31, AX
+	MOVQ AX, n+8(FP)
+	RET
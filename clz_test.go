@@ -21,6 +21,22 @@ func TestCLZRandom(t *testing.T) {
 	testClzRandom(t, clz)
 }
 
+func TestCLZ32GoSimple(t *testing.T) {
+	testClz32Simple(t, clz32_g)
+}
+
+func TestCLZ32GoRandom(t *testing.T) {
+	testClz32Random(t, clz32_g)
+}
+
+func TestCLZ32Simple(t *testing.T) {
+	testClz32Simple(t, clz32)
+}
+
+func TestCLZ32Random(t *testing.T) {
+	testClz32Random(t, clz32)
+}
+
 func BenchmarkCLZGo(b *testing.B) {
 	for i := 0; i < b.N*b.N; i++ {
 		clz_g(uint64(rand.Uint32())<<32 | uint64(rand.Uint32()))
@@ -33,6 +49,18 @@ func BenchmarkCLZ(b *testing.B) {
 	}
 }
 
+func BenchmarkCLZ32Go(b *testing.B) {
+	for i := 0; i < b.N*b.N; i++ {
+		clz32_g(rand.Uint32())
+	}
+}
+
+func BenchmarkCLZ32(b *testing.B) {
+	for i := 0; i < b.N*b.N; i++ {
+		clz32(rand.Uint32())
+	}
+}
+
 func testClzSimple(t *testing.T, clzImpl func(uint64) int) {
 	x := uint64(1)
 	for i := 0; i < 64; i++ {
@@ -56,3 +84,27 @@ func testClzRandom(t *testing.T, clzImpl func(uint64) int) {
 		t.Errorf("clz failed: expected %d, got %d\n", 0, y)
 	}
 }
+
+func testClz32Simple(t *testing.T, clzImpl func(uint32) int) {
+	x := uint32(1)
+	for i := 0; i < 32; i++ {
+		if y := clzImpl(x); y != (31 - i) {
+			t.Errorf("clz failed: expected %d, got %d\n", (31 - i), y)
+		}
+		x <<= 1
+	}
+}
+
+func testClz32Random(t *testing.T, clzImpl func(uint32) int) {
+	x := uint32(1)
+	for i := 0; i < 31; i++ {
+		temp := x | uint32(rand.Intn(int(x)))
+		if y := clzImpl(temp); y != (31 - i) {
+			t.Errorf("clz failed: expected %d, got %d\n", (31 - i), y)
+		}
+		x <<= 1
+	}
+	if y := clzImpl(x | rand.Uint32()); y != 0 {
+		t.Errorf("clz failed: expected %d, got %d\n", 0, y)
+	}
+}
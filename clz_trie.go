@@ -2,29 +2,44 @@ package tree
 
 // Simple bitwise trie implementation.
 
+import (
+	"iter"
+	"math"
+)
+
 // Bitwise trie using count-leading-zeroes as a hint into the tree.
-type clzTrie struct {
+type clzTrie[V any] struct {
 	// Random-access into the nodes starting with zero bits.
-	zeroNodes [64]*trieNode
+	zeroNodes [64]*trieNode[V]
+
+	// Number of keys in the trie.
+	size int
 }
 
 // NewCLZTrie creates an empty binary trie. This trie implementation is
 // optimized for lexicographically small keys.
 func NewCLZTrie() Trie {
-	ctr := new(clzTrie)
-	ctr.zeroNodes[63] = new(trieNode)
+	return NewCLZTrieOf[interface{}]()
+}
+
+// NewCLZTrieOf creates an empty CLZ trie with values of type V. See
+// NewCLZTrie.
+func NewCLZTrieOf[V any]() TrieOf[V] {
+	ctr := new(clzTrie[V])
+	ctr.zeroNodes[63] = new(trieNode[V])
 	for i := 62; i >= 0; i-- {
-		ctr.zeroNodes[i] = new(trieNode)
+		ctr.zeroNodes[i] = new(trieNode[V])
 		ctr.zeroNodes[i].children[0] = ctr.zeroNodes[i+1]
 	}
 	return ctr
 }
 
-func (ctr *clzTrie) Get(key uint64) (interface{}, bool) {
+func (ctr *clzTrie[V]) Get(key uint64) (V, bool) {
 	if key == 0 {
 		node := ctr.zeroNodes[63].children[0]
 		if node == nil {
-			return nil, false
+			var value V
+			return value, false
 		} else {
 			return node.value, true
 		}
@@ -40,7 +55,8 @@ func (ctr *clzTrie) Get(key uint64) (interface{}, bool) {
 			}
 
 			if node == nil {
-				return nil, false
+				var value V
+				return value, false
 			}
 		}
 
@@ -49,12 +65,14 @@ func (ctr *clzTrie) Get(key uint64) (interface{}, bool) {
 
 }
 
-func (ctr *clzTrie) Set(key uint64, value interface{}) (interface{}, bool) {
+func (ctr *clzTrie[V]) Set(key uint64, value V) (V, bool) {
 	if key == 0 {
 		node := ctr.zeroNodes[63]
 		if child := node.children[0]; child == nil {
-			node.children[0] = &trieNode{value: value}
-			return nil, false
+			node.children[0] = &trieNode[V]{value: value}
+			ctr.size++
+			var origValue V
+			return origValue, false
 		} else {
 			origValue := child.value
 			child.value = value
@@ -67,12 +85,12 @@ func (ctr *clzTrie) Set(key uint64, value interface{}) (interface{}, bool) {
 		for i := uint(64 - lz); i > 1; i-- {
 			if key&(1<<(i-1)) == 0 {
 				if node.children[0] == nil {
-					node.children[0] = new(trieNode)
+					node.children[0] = new(trieNode[V])
 				}
 				node = node.children[0]
 			} else {
 				if node.children[1] == nil {
-					node.children[1] = new(trieNode)
+					node.children[1] = new(trieNode[V])
 				}
 				node = node.children[1]
 			}
@@ -80,8 +98,10 @@ func (ctr *clzTrie) Set(key uint64, value interface{}) (interface{}, bool) {
 
 		idx := key & 1
 		if node.children[idx] == nil {
-			node.children[idx] = &trieNode{value: value}
-			return nil, false
+			node.children[idx] = &trieNode[V]{value: value}
+			ctr.size++
+			var origValue V
+			return origValue, false
 		} else {
 			origValue := node.children[idx].value
 			node.children[idx].value = value
@@ -90,21 +110,27 @@ func (ctr *clzTrie) Set(key uint64, value interface{}) (interface{}, bool) {
 	}
 }
 
-func (ctr *clzTrie) Del(key uint64) (interface{}, bool) {
+func (ctr *clzTrie[V]) Del(key uint64) (V, bool) {
 	if key == 0 {
 		node := ctr.zeroNodes[63]
 		if node.children[0] == nil {
-			return nil, false
+			var value V
+			return value, false
 		} else {
 			origValue := node.children[0].value
 			node.children[0] = nil
+			ctr.size--
 			return origValue, true
 		}
 	} else {
+		// Remember the path so that nodes left empty can be pruned. path[d]
+		// is the node at depth d.
+		var path [64]*trieNode[V]
 		lz := clz(key)
 		node := ctr.zeroNodes[lz]
 
 		for i := uint(64 - lz); i > 1; i-- {
+			path[64-i] = node
 			if key&(1<<(i-1)) == 0 {
 				node = node.children[0]
 			} else {
@@ -112,17 +138,93 @@ func (ctr *clzTrie) Del(key uint64) (interface{}, bool) {
 			}
 
 			if node == nil {
-				return nil, false
+				var value V
+				return value, false
 			}
 		}
+		path[63] = node
 
 		idx := key & 1
 		if node.children[idx] == nil {
-			return nil, false
-		} else {
-			origValue := node.children[idx].value
-			node.children[idx] = nil
-			return origValue, true
+			var value V
+			return value, false
+		}
+		origValue := node.children[idx].value
+		node.children[idx] = nil
+		ctr.size--
+
+		// Remove the nodes left without children, stopping at the zero node
+		// the path started from, which is always kept.
+		for d := 63; d > lz; d-- {
+			node = path[d]
+			if node.children[0] != nil || node.children[1] != nil {
+				break
+			}
+			path[d-1].children[(key>>(64-d))&1] = nil
+		}
+		return origValue, true
+	}
+}
+
+func (ctr *clzTrie[V]) Ascend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		ctr.walk(0, math.MaxUint64, false, yield)
+	}
+}
+
+func (ctr *clzTrie[V]) Descend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		ctr.walk(0, math.MaxUint64, true, yield)
+	}
+}
+
+func (ctr *clzTrie[V]) Range(lo, hi uint64) iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		if lo < hi {
+			ctr.walk(lo, hi-1, false, yield)
 		}
 	}
 }
+
+func (ctr *clzTrie[V]) Min() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).min()
+}
+
+func (ctr *clzTrie[V]) Max() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).max()
+}
+
+func (ctr *clzTrie[V]) Floor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).floor(key)
+}
+
+func (ctr *clzTrie[V]) Ceiling(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).ceiling(key)
+}
+
+func (ctr *clzTrie[V]) Predecessor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).predecessor(key)
+}
+
+func (ctr *clzTrie[V]) Successor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](ctr.walk).successor(key)
+}
+
+func (ctr *clzTrie[V]) Len() int {
+	return ctr.size
+}
+
+func (ctr *clzTrie[V]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	trieWalkFunc[uint64, V](ctr.walk).prefix(prefix, bits, fn)
+}
+
+func (ctr *clzTrie[V]) CountPrefix(prefix uint64, bits uint) int {
+	return trieWalkFunc[uint64, V](ctr.walk).countPrefix(prefix, bits)
+}
+
+func (ctr *clzTrie[V]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	// The node for zero leading zeroes is the root of the whole trie.
+	return ctr.zeroNodes[0].walk(0, 0, lo, hi, reverse, func(key uint64, leaf *trieNode[V]) bool {
+		return yield(key, leaf.value)
+	})
+}
@@ -0,0 +1,181 @@
+package tree
+
+// Bitwise trie implementation with 32-bit keys using count-leading-zeroes.
+
+import (
+	"iter"
+	"math"
+)
+
+// Bitwise trie with 32-bit keys using count-leading-zeroes as a hint into the
+// tree.
+type clzTrie32[V any] struct {
+	// Random-access into the nodes starting with zero bits.
+	zeroNodes [32]*trieNode[V]
+
+	// Number of keys in the trie.
+	size int
+}
+
+// NewCLZTrie32 creates an empty CLZ trie with uint32 keys. See NewCLZTrie.
+func NewCLZTrie32() Trie32 {
+	return NewCLZTrie32Of[interface{}]()
+}
+
+// NewCLZTrie32Of creates an empty CLZ trie with uint32 keys and values of type
+// V. See NewCLZTrie32.
+func NewCLZTrie32Of[V any]() Trie32Of[V] {
+	ctr := new(clzTrie32[V])
+	ctr.zeroNodes[31] = new(trieNode[V])
+	for i := 30; i >= 0; i-- {
+		ctr.zeroNodes[i] = new(trieNode[V])
+		ctr.zeroNodes[i].children[0] = ctr.zeroNodes[i+1]
+	}
+	return ctr
+}
+
+// start returns the node to start from for the given key and the number of
+// bits of the key below it. Zero has the same path as one up to the last bit.
+func (ctr *clzTrie32[V]) start(key uint32) (*trieNode[V], uint) {
+	if key == 0 {
+		return ctr.zeroNodes[31], 1
+	}
+	lz := clz32(key)
+	return ctr.zeroNodes[lz], uint(32 - lz)
+}
+
+func (ctr *clzTrie32[V]) Get(key uint32) (V, bool) {
+	node, n := ctr.start(key)
+
+	for i := n; i > 0; i-- {
+		node = node.children[(key>>(i-1))&1]
+		if node == nil {
+			var value V
+			return value, false
+		}
+	}
+
+	return node.value, true
+}
+
+func (ctr *clzTrie32[V]) Set(key uint32, value V) (V, bool) {
+	node, n := ctr.start(key)
+
+	for i := n; i > 1; i-- {
+		idx := (key >> (i - 1)) & 1
+		if node.children[idx] == nil {
+			node.children[idx] = new(trieNode[V])
+		}
+		node = node.children[idx]
+	}
+
+	idx := key & 1
+	if node.children[idx] == nil {
+		node.children[idx] = &trieNode[V]{value: value}
+		ctr.size++
+		var origValue V
+		return origValue, false
+	} else {
+		origValue := node.children[idx].value
+		node.children[idx].value = value
+		return origValue, true
+	}
+}
+
+func (ctr *clzTrie32[V]) Del(key uint32) (V, bool) {
+	// Remember the path so that nodes left empty can be pruned. path[d] is
+	// the node at depth d.
+	var path [32]*trieNode[V]
+	node, n := ctr.start(key)
+
+	for i := n; i > 1; i-- {
+		path[32-i] = node
+		node = node.children[(key>>(i-1))&1]
+		if node == nil {
+			var value V
+			return value, false
+		}
+	}
+	path[31] = node
+
+	idx := key & 1
+	if node.children[idx] == nil {
+		var value V
+		return value, false
+	}
+	origValue := node.children[idx].value
+	node.children[idx] = nil
+	ctr.size--
+
+	// Remove the nodes left without children, stopping at the zero node the
+	// path started from, which is always kept.
+	for d := uint(31); d > 32-n; d-- {
+		node = path[d]
+		if node.children[0] != nil || node.children[1] != nil {
+			break
+		}
+		path[d-1].children[(key>>(32-d))&1] = nil
+	}
+	return origValue, true
+}
+
+func (ctr *clzTrie32[V]) Ascend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		ctr.walk(0, math.MaxUint32, false, yield)
+	}
+}
+
+func (ctr *clzTrie32[V]) Descend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		ctr.walk(0, math.MaxUint32, true, yield)
+	}
+}
+
+func (ctr *clzTrie32[V]) Range(lo, hi uint32) iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		if lo < hi {
+			ctr.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (ctr *clzTrie32[V]) Min() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).min()
+}
+
+func (ctr *clzTrie32[V]) Max() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).max()
+}
+
+func (ctr *clzTrie32[V]) Floor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).floor(key)
+}
+
+func (ctr *clzTrie32[V]) Ceiling(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).ceiling(key)
+}
+
+func (ctr *clzTrie32[V]) Predecessor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).predecessor(key)
+}
+
+func (ctr *clzTrie32[V]) Successor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](ctr.walk).successor(key)
+}
+
+func (ctr *clzTrie32[V]) Len() int {
+	return ctr.size
+}
+
+func (ctr *clzTrie32[V]) WalkPrefix(prefix uint32, bits uint, fn func(uint32, V) bool) {
+	trieWalkFunc[uint32, V](ctr.walk).prefix(prefix, bits, fn)
+}
+
+func (ctr *clzTrie32[V]) CountPrefix(prefix uint32, bits uint) int {
+	return trieWalkFunc[uint32, V](ctr.walk).countPrefix(prefix, bits)
+}
+
+func (ctr *clzTrie32[V]) walk(lo, hi uint32, reverse bool, yield func(uint32, V) bool) bool {
+	// The node for zero leading zeroes is the root of the whole trie.
+	return ctr.zeroNodes[0].walk32(lo, hi, reverse, yield)
+}
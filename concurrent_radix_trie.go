@@ -0,0 +1,290 @@
+package tree
+
+// Path-compressed radix trie with lock-free readers.
+
+import (
+	"iter"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent radix trie.
+type concurrentRadixTrie[V any] struct {
+	root atomic.Pointer[concurrentRadixNode[V]]
+
+	// Number of keys in the trie.
+	size atomic.Int64
+
+	// Serializes writers.
+	lock sync.Mutex
+}
+
+// Node or leaf in a concurrent radix trie. Once a node is reachable by readers,
+// only its children are modified, and a leaf is replaced instead of being
+// modified.
+type concurrentRadixNode[V any] struct {
+	key   uint64
+	level uint
+	value V
+
+	// Number of non-nil children. Only accessed by writers.
+	count uint
+
+	// Children of a node, or nil for a leaf.
+	children *[RADIX_COUNT]atomic.Pointer[concurrentRadixNode[V]]
+}
+
+// NewConcurrentRadixTrie creates an empty path-compressed radix trie which is
+// safe for concurrent use. Get is wait-free: it never takes a lock and finishes
+// in a bounded number of steps regardless of concurrent writers. Writers are
+// serialized by a lock and publish their changes with atomic stores, so
+// readers always see either the old or the new version of a key. Iteration and
+// the other ordered queries do not block either, but they are not atomic with
+// respect to concurrent writes: they see every key which is present for their
+// whole duration and no key which is absent for it.
+func NewConcurrentRadixTrie() Trie {
+	return NewConcurrentRadixTrieOf[interface{}]()
+}
+
+// NewConcurrentRadixTrieOf creates an empty concurrent radix trie with values
+// of type V. See NewConcurrentRadixTrie.
+func NewConcurrentRadixTrieOf[V any]() TrieOf[V] {
+	return new(concurrentRadixTrie[V])
+}
+
+func (crtrie *concurrentRadixTrie[V]) Get(key uint64) (V, bool) {
+	node := crtrie.root.Load()
+
+	for node != nil {
+		if node.children == nil {
+			if node.key == key {
+				return node.value, true
+			} else {
+				break
+			}
+		}
+		if node.notDescendant(key) {
+			break
+		}
+		node = node.children[radixSlot(key, node.level, RADIX_WIDTH)].Load()
+	}
+	var value V
+	return value, false
+}
+
+func (crtrie *concurrentRadixTrie[V]) Set(key uint64, value V) (V, bool) {
+	crtrie.lock.Lock()
+	defer crtrie.lock.Unlock()
+
+	leaf := &concurrentRadixNode[V]{key: key, value: value}
+	parent := &crtrie.root
+	node := parent.Load()
+
+	if node == nil {
+		parent.Store(leaf)
+		crtrie.size.Add(1)
+		var origValue V
+		return origValue, false
+	}
+
+	for {
+		if node.children == nil && node.key == key {
+			parent.Store(leaf)
+			return node.value, true
+		}
+
+		if node.children == nil || node.notDescendant(key) {
+			// Fully build the new node before publishing it.
+			level := radixDiffLevel(key, node.key, RADIX_WIDTH)
+			newNode := newConcurrentRadixNode[V](radixTrimKey(key, level+1, RADIX_WIDTH), level)
+			newNode.children[radixSlot(key, level, RADIX_WIDTH)].Store(leaf)
+			newNode.children[radixSlot(node.key, level, RADIX_WIDTH)].Store(node)
+			newNode.count = 2
+			parent.Store(newNode)
+			crtrie.size.Add(1)
+			var origValue V
+			return origValue, false
+		}
+
+		slot := &node.children[radixSlot(key, node.level, RADIX_WIDTH)]
+		child := slot.Load()
+		if child == nil {
+			slot.Store(leaf)
+			node.count++
+			crtrie.size.Add(1)
+			var origValue V
+			return origValue, false
+		}
+		parent = slot
+		node = child
+	}
+}
+
+func (crtrie *concurrentRadixTrie[V]) Del(key uint64) (V, bool) {
+	crtrie.lock.Lock()
+	defer crtrie.lock.Unlock()
+
+	parent := &crtrie.root
+	node := parent.Load()
+
+	if node != nil && node.children == nil {
+		if node.key == key {
+			parent.Store(nil)
+			crtrie.size.Add(-1)
+			return node.value, true
+		}
+		node = nil
+	}
+
+	for node != nil {
+		slot := &node.children[radixSlot(key, node.level, RADIX_WIDTH)]
+		child := slot.Load()
+		if child == nil {
+			break
+		}
+		if child.children != nil {
+			parent = slot
+			node = child
+			continue
+		}
+		if child.key != key {
+			break
+		}
+
+		slot.Store(nil)
+		node.count--
+		crtrie.size.Add(-1)
+		if node.count > 1 {
+			return child.value, true
+		}
+
+		// Replace the node with its only remaining child. Readers which
+		// already reached the node can still find the child through it.
+		i := 0
+		for ; i < RADIX_COUNT; i++ {
+			if node.children[i].Load() != nil {
+				break
+			}
+		}
+		if i == RADIX_COUNT {
+			panic("incorrect count in radix trie")
+		}
+		parent.Store(node.children[i].Load())
+		return child.value, true
+	}
+
+	var value V
+	return value, false
+}
+
+func (crtrie *concurrentRadixTrie[V]) Ascend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		crtrie.walk(0, math.MaxUint64, false, yield)
+	}
+}
+
+func (crtrie *concurrentRadixTrie[V]) Descend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		crtrie.walk(0, math.MaxUint64, true, yield)
+	}
+}
+
+func (crtrie *concurrentRadixTrie[V]) Range(lo, hi uint64) iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		if lo < hi {
+			crtrie.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (crtrie *concurrentRadixTrie[V]) Min() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).min()
+}
+
+func (crtrie *concurrentRadixTrie[V]) Max() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).max()
+}
+
+func (crtrie *concurrentRadixTrie[V]) Floor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).floor(key)
+}
+
+func (crtrie *concurrentRadixTrie[V]) Ceiling(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).ceiling(key)
+}
+
+func (crtrie *concurrentRadixTrie[V]) Predecessor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).predecessor(key)
+}
+
+func (crtrie *concurrentRadixTrie[V]) Successor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](crtrie.walk).successor(key)
+}
+
+func (crtrie *concurrentRadixTrie[V]) Len() int {
+	return int(crtrie.size.Load())
+}
+
+func (crtrie *concurrentRadixTrie[V]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	trieWalkFunc[uint64, V](crtrie.walk).prefix(prefix, bits, fn)
+}
+
+func (crtrie *concurrentRadixTrie[V]) CountPrefix(prefix uint64, bits uint) int {
+	return trieWalkFunc[uint64, V](crtrie.walk).countPrefix(prefix, bits)
+}
+
+func (crtrie *concurrentRadixTrie[V]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	return crtrie.root.Load().walk(lo, hi, reverse, yield)
+}
+
+// walk calls yield on every leaf underneath the node with a key between lo and
+// hi, inclusive. See radixWalk.
+func (node *concurrentRadixNode[V]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if node.children == nil {
+		if node.key < lo || node.key > hi {
+			return true
+		}
+		return yield(node.key, node.value)
+	}
+
+	if node.last() < lo || node.key > hi {
+		return true
+	}
+	for i := 0; i < RADIX_COUNT; i++ {
+		slot := i
+		if reverse {
+			slot = RADIX_MASK - i
+		}
+		if !node.children[slot].Load().walk(lo, hi, reverse, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func newConcurrentRadixNode[V any](key uint64, level uint) *concurrentRadixNode[V] {
+	return &concurrentRadixNode[V]{
+		key:      key,
+		level:    level,
+		children: new([RADIX_COUNT]atomic.Pointer[concurrentRadixNode[V]]),
+	}
+}
+
+// notDescendant returns true if the given key can be determined to not be
+// underneath the given node.
+func (node *concurrentRadixNode[V]) notDescendant(key uint64) bool {
+	if node.level < RADIX_LIMIT {
+		return radixTrimKey(key, node.level+1, RADIX_WIDTH) != node.key
+	} else {
+		return false
+	}
+}
+
+// last returns the largest key which could be underneath the given node.
+func (node *concurrentRadixNode[V]) last() uint64 {
+	return node.key | (1<<((node.level+1)*RADIX_WIDTH) - 1)
+}
@@ -0,0 +1,107 @@
+package tree
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentRadixTrieReaders(t *testing.T) {
+	crtrie := NewConcurrentRadixTrieOf[int]()
+
+	// Even keys are never deleted; odd keys are churned by the writer.
+	for i := 0; i < NUM_NODES; i += 2 {
+		crtrie.Set(uint64(i), i)
+	}
+
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	for g := 0; g < NUM_GOROUTINES; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !done.Load() {
+				k := rand.Intn(NUM_NODES)
+				v, ok := crtrie.Get(uint64(k))
+				if k%2 == 0 && (!ok || v != k) {
+					t.Errorf("get failed: got %v, %v for %v\n", v, ok, k)
+					return
+				}
+				if ok && v != k && v != -k {
+					t.Errorf("get failed: got %v for %v\n", v, k)
+					return
+				}
+
+				prev := -1
+				for k, v := range crtrie.Range(uint64(k), uint64(k+100)) {
+					if int(k) <= prev || (v != int(k) && v != -int(k)) {
+						t.Errorf("range failed: got %v, %v after %v\n", k, v, prev)
+						return
+					}
+					prev = int(k)
+				}
+			}
+		}()
+	}
+
+	for _, v := range testRand.Perm(4 * NUM_NODES) {
+		k := uint64(2*(v%(NUM_NODES/2)) + 1)
+		switch v % 3 {
+		case 0:
+			crtrie.Del(k)
+		case 1:
+			crtrie.Set(k, int(k))
+		case 2:
+			crtrie.Set(k, -int(k))
+		}
+	}
+	done.Store(true)
+	wg.Wait()
+
+	for i := 0; i < NUM_NODES; i += 2 {
+		crtrie.Set(uint64(i+1), i+1)
+	}
+	for i := 0; i < NUM_NODES; i++ {
+		if v, ok := crtrie.Get(uint64(i)); !ok || v != i {
+			t.Fatalf("get failed: got %v, %v for %v\n", v, ok, i)
+		}
+	}
+}
+
+// benchmarkParallelGet benchmarks Get from many goroutines while another
+// goroutine keeps modifying the trie.
+func benchmarkParallelGet(b *testing.B, trie Trie) {
+	for i := 0; i < NUM_NODES; i++ {
+		trie.Set(uint64(i), i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			trie.Set(uint64(NUM_NODES+i%NUM_NODES), i)
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			trie.Get(uint64(i % NUM_NODES))
+		}
+	})
+	b.StopTimer()
+	close(done)
+}
+
+func BenchmarkSynchronizedRadixTrieParallelGet(b *testing.B) {
+	benchmarkParallelGet(b, NewSynchronizedTrie(NewRadixTrie()))
+}
+
+func BenchmarkConcurrentRadixTrieParallelGet(b *testing.B) {
+	benchmarkParallelGet(b, NewConcurrentRadixTrie())
+}
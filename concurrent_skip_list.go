@@ -0,0 +1,383 @@
+package tree
+
+// Concurrent lazy skip list implementation.
+
+import (
+	"cmp"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent skip list.
+type concurrentSkipList[K, V any] struct {
+	// Sentinel node before the first node on every level.
+	head concurrentSkipListNode[K, V]
+
+	// Number of nodes in the list.
+	size atomic.Int64
+
+	// Comparison function for keys.
+	compare func(K, K) int
+}
+
+// Node in a concurrent skip list.
+type concurrentSkipListNode[K, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+
+	// Next node on each level that this node is on.
+	next []atomic.Pointer[concurrentSkipListNode[K, V]]
+
+	// Held while the node's value or next pointers are modified.
+	lock sync.Mutex
+
+	// Whether the node has been logically deleted. A marked node is unlinked
+	// by the goroutine which marked it.
+	marked atomic.Bool
+
+	// Whether the node has been linked on every level it is on.
+	fullyLinked atomic.Bool
+}
+
+// NewConcurrentSkipList creates an empty skip list which is safe for
+// concurrent use. It is a lazy skip list: Set and Del lock only the nodes
+// adjacent to the key they modify, so writers to different parts of the list
+// proceed in parallel, and Get takes no locks at all. Get, Set, and Del are
+// linearizable. Iteration and the other ordered queries take no locks and
+// tolerate concurrent modification: they see every key which is present for
+// their whole duration and no key which is absent for it. Descend and Max are
+// O(log n) expected per key since the list is only linked forwards.
+func NewConcurrentSkipList() Tree {
+	return NewConcurrentSkipListOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewConcurrentSkipListOf creates an empty concurrent skip list ordered by the
+// natural order of its keys. See NewConcurrentSkipList.
+func NewConcurrentSkipListOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewConcurrentSkipListOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewConcurrentSkipListOfFunc creates an empty concurrent skip list ordered by
+// the given comparison function. See NewConcurrentSkipList and NewBSTOfFunc.
+func NewConcurrentSkipListOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	sl := &concurrentSkipList[K, V]{compare: compare}
+	sl.head.next = make([]atomic.Pointer[concurrentSkipListNode[K, V]], skipListMaxLevel)
+	sl.head.fullyLinked.Store(true)
+	return sl
+}
+
+// NewConcurrentSkipListFunc creates an empty concurrent skip list ordered by
+// the given comparison function. See NewConcurrentSkipList and NewBSTFunc.
+func NewConcurrentSkipListFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewConcurrentSkipListOfFunc[interface{}, interface{}](compare)
+}
+
+func (sl *concurrentSkipList[K, V]) Get(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	level := sl.find(key, &preds, &succs)
+
+	if level >= 0 && succs[level].live() {
+		return *succs[level].value.Load(), true
+	} else {
+		var value V
+		return value, false
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Set(key K, value V) (V, bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	topLevel := skipListRandomLevel()
+
+	for {
+		if level := sl.find(key, &preds, &succs); level >= 0 {
+			node := succs[level]
+			if node.marked.Load() {
+				// It is being deleted; try again once it is unlinked.
+				runtime.Gosched()
+				continue
+			}
+			for !node.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+
+			node.lock.Lock()
+			if node.marked.Load() {
+				node.lock.Unlock()
+				continue
+			}
+			origValue := node.value.Swap(&value)
+			node.lock.Unlock()
+			return *origValue, true
+		}
+
+		// Lock the predecessors and check that they are still linked to the
+		// successors.
+		highestLocked := -1
+		valid := true
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if level == 0 || pred != preds[level-1] {
+				pred.lock.Lock()
+				highestLocked = level
+			}
+			valid = !pred.marked.Load() &&
+				(succ == nil || !succ.marked.Load()) &&
+				pred.next[level].Load() == succ
+		}
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		node := &concurrentSkipListNode[K, V]{
+			key:  key,
+			next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], topLevel),
+		}
+		node.value.Store(&value)
+		for level := 0; level < topLevel; level++ {
+			node.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(node)
+		}
+		node.fullyLinked.Store(true)
+		unlockPreds(&preds, highestLocked)
+
+		sl.size.Add(1)
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Del(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	var victim *concurrentSkipListNode[K, V]
+
+	for {
+		level := sl.find(key, &preds, &succs)
+
+		if victim == nil {
+			// Only a fully linked node found on its top level can be deleted;
+			// otherwise, it is still being inserted or is already being
+			// deleted.
+			if level < 0 {
+				var value V
+				return value, false
+			}
+			node := succs[level]
+			if !node.fullyLinked.Load() || len(node.next) != level+1 || node.marked.Load() {
+				var value V
+				return value, false
+			}
+
+			node.lock.Lock()
+			if node.marked.Load() {
+				node.lock.Unlock()
+				var value V
+				return value, false
+			}
+			node.marked.Store(true)
+			victim = node
+		}
+
+		// The victim is locked and marked, so it stays in place until it is
+		// unlinked here.
+		highestLocked := -1
+		valid := true
+		for level := 0; valid && level < len(victim.next); level++ {
+			pred := preds[level]
+			if level == 0 || pred != preds[level-1] {
+				pred.lock.Lock()
+				highestLocked = level
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == victim
+		}
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		for level := len(victim.next) - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		value := *victim.value.Load()
+		victim.lock.Unlock()
+		unlockPreds(&preds, highestLocked)
+
+		sl.size.Add(-1)
+		return value, true
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.ascendTo(sl.head.next[0].Load(), nil, yield)
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := sl.last(nil, false); node != nil; node = sl.last(&node.key, false) {
+			if !yield(node.key, *node.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.ascendTo(sl.first(lo, true), &hi, yield)
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) Min() (K, V, bool) {
+	return sl.entry(sl.real(sl.head.next[0].Load()))
+}
+
+func (sl *concurrentSkipList[K, V]) Max() (K, V, bool) {
+	return sl.entry(sl.last(nil, false))
+}
+
+func (sl *concurrentSkipList[K, V]) Floor(key K) (K, V, bool) {
+	return sl.entry(sl.last(&key, true))
+}
+
+func (sl *concurrentSkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return sl.entry(sl.first(key, true))
+}
+
+func (sl *concurrentSkipList[K, V]) Predecessor(key K) (K, V, bool) {
+	return sl.entry(sl.last(&key, false))
+}
+
+func (sl *concurrentSkipList[K, V]) Successor(key K) (K, V, bool) {
+	return sl.entry(sl.first(key, false))
+}
+
+func (sl *concurrentSkipList[K, V]) Len() int {
+	return int(sl.size.Load())
+}
+
+// find fills in the last node before the given key and the node after it on
+// every level and returns the highest level on which the node with the given
+// key was found, or -1 if it was not found. The returned nodes may be marked
+// or not fully linked.
+func (sl *concurrentSkipList[K, V]) find(key K, preds, succs *[skipListMaxLevel]*concurrentSkipListNode[K, V]) int {
+	found := -1
+	pred := &sl.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && sl.compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found < 0 && curr != nil && sl.compare(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// first returns the first live node with a key greater than the given key, or
+// greater than or equal to it if inclusive is true.
+func (sl *concurrentSkipList[K, V]) first(key K, inclusive bool) *concurrentSkipListNode[K, V] {
+	pred := &sl.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		for {
+			curr := pred.next[level].Load()
+			if curr == nil {
+				break
+			}
+			if c := sl.compare(curr.key, key); c > 0 || (inclusive && c == 0) {
+				break
+			}
+			pred = curr
+		}
+	}
+	return sl.real(pred.next[0].Load())
+}
+
+// last returns the last live node with a key less than the given key, or less
+// than or equal to it if inclusive is true. If the key is nil, it returns the
+// last live node in the list.
+func (sl *concurrentSkipList[K, V]) last(key *K, inclusive bool) *concurrentSkipListNode[K, V] {
+	for {
+		pred := &sl.head
+		for level := skipListMaxLevel - 1; level >= 0; level-- {
+			for {
+				curr := pred.next[level].Load()
+				if curr == nil {
+					break
+				}
+				if key != nil {
+					if c := sl.compare(curr.key, *key); c > 0 || (!inclusive && c == 0) {
+						break
+					}
+				}
+				pred = curr
+			}
+		}
+
+		if pred == &sl.head {
+			return nil
+		} else if pred.live() {
+			return pred
+		}
+		// Look for the last live node before the dead one.
+		key, inclusive = &pred.key, false
+	}
+}
+
+// real returns the given node if it is live or the next live node after it
+// otherwise. The node may be nil.
+func (sl *concurrentSkipList[K, V]) real(node *concurrentSkipListNode[K, V]) *concurrentSkipListNode[K, V] {
+	for node != nil && !node.live() {
+		node = node.next[0].Load()
+	}
+	return node
+}
+
+// ascendTo calls yield on the given node and the live nodes following it until
+// it reaches a key greater than or equal to hi, or the end of the list if hi is
+// nil. The node may be nil.
+func (sl *concurrentSkipList[K, V]) ascendTo(node *concurrentSkipListNode[K, V], hi *K, yield func(K, V) bool) {
+	for node = sl.real(node); node != nil; node = sl.real(node.next[0].Load()) {
+		if hi != nil && sl.compare(node.key, *hi) >= 0 {
+			return
+		}
+		if !yield(node.key, *node.value.Load()) {
+			return
+		}
+	}
+}
+
+// entry returns the key and value of the given node, or false if it is nil.
+func (sl *concurrentSkipList[K, V]) entry(node *concurrentSkipListNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	} else {
+		return node.key, *node.value.Load(), true
+	}
+}
+
+// live returns whether the node is in the list: fully linked and not marked.
+func (node *concurrentSkipListNode[K, V]) live() bool {
+	return node.fullyLinked.Load() && !node.marked.Load()
+}
+
+// unlockPreds unlocks the distinct predecessors locked on levels up to and
+// including highestLocked.
+func unlockPreds[K, V any](preds *[skipListMaxLevel]*concurrentSkipListNode[K, V], highestLocked int) {
+	for level := 0; level <= highestLocked; level++ {
+		if level == 0 || preds[level] != preds[level-1] {
+			preds[level].lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package tree
+
+import (
+	"iter"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// testConcurrentAgainst runs random operations on a concurrent skip list from
+// many goroutines, each of which owns a disjoint set of keys and checks every
+// result against its own sequential tree. Afterwards, the list must contain
+// exactly the union of the sequential trees.
+func testConcurrentAgainst(t *testing.T, newTree func() Tree) {
+	const numKeys = NUM_NODES / 4
+
+	list := NewConcurrentSkipList()
+	models := make([]Tree, NUM_GOROUTINES)
+	var wg sync.WaitGroup
+	for g := range models {
+		models[g] = newTree()
+		wg.Add(1)
+		go func(g int, model Tree) {
+			defer wg.Done()
+			for i := 0; i < NUM_NODES; i++ {
+				k := Uint64Key(rand.Intn(numKeys)*NUM_GOROUTINES + g)
+				switch rand.Intn(3) {
+				case 0:
+					v := rand.Int()
+					lv, lok := list.Set(k, v)
+					mv, mok := model.Set(k, v)
+					if lv != mv || lok != mok {
+						t.Errorf("set failed: got %v, %v for %v, expected %v, %v\n", lv, lok, k, mv, mok)
+						return
+					}
+				case 1:
+					lv, lok := list.Del(k)
+					mv, mok := model.Del(k)
+					if lv != mv || lok != mok {
+						t.Errorf("delete failed: got %v, %v for %v, expected %v, %v\n", lv, lok, k, mv, mok)
+						return
+					}
+				case 2:
+					lv, lok := list.Get(k)
+					mv, mok := model.Get(k)
+					if lv != mv || lok != mok {
+						t.Errorf("get failed: got %v, %v for %v, expected %v, %v\n", lv, lok, k, mv, mok)
+						return
+					}
+				}
+			}
+		}(g, models[g])
+	}
+	wg.Wait()
+
+	merged := newTree()
+	for _, model := range models {
+		for k, v := range model.Ascend() {
+			merged.Set(k, v)
+		}
+	}
+	if n, m := list.Len(), merged.Len(); n != m {
+		t.Errorf("len failed: got %v, expected %v\n", n, m)
+	}
+	next, stop := iter.Pull2(merged.Ascend())
+	defer stop()
+	for k, v := range list.Ascend() {
+		mk, mv, ok := next()
+		if !ok || k != mk || v != mv {
+			t.Fatalf("ascend failed: got %v, %v, expected %v, %v, %v\n", k, v, mk, mv, ok)
+		}
+	}
+	if mk, _, ok := next(); ok {
+		t.Errorf("ascend failed: missing %v\n", mk)
+	}
+	for k, v := range list.Descend() {
+		if _, mv, ok := merged.Max(); !ok || v != mv {
+			t.Fatalf("descend failed: got %v, %v, expected %v\n", k, v, mv)
+		}
+		merged.Del(k)
+	}
+}
+
+func TestConcurrentSkipListAgainstSkipList(t *testing.T) {
+	testConcurrentAgainst(t, NewSkipList)
+}
+
+func TestConcurrentSkipListAgainstRedBlack(t *testing.T) {
+	testConcurrentAgainst(t, NewRedBlack)
+}
+
+func TestConcurrentSkipListAgainstBTree(t *testing.T) {
+	testConcurrentAgainst(t, func() Tree { return NewBTree(16) })
+}
+
+// TestConcurrentSkipListContention has every goroutine fight over a few keys.
+// For each key, the number of successful inserts minus the number of
+// successful deletes must match whether the key is in the list at the end.
+func TestConcurrentSkipListContention(t *testing.T) {
+	const numKeys = 16
+
+	list := NewConcurrentSkipList()
+	var balance [numKeys]atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < NUM_GOROUTINES; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < NUM_NODES; i++ {
+				k := rand.Intn(numKeys)
+				if rand.Intn(2) == 0 {
+					if _, ok := list.Set(Uint64Key(k), k); !ok {
+						balance[k].Add(1)
+					}
+				} else if v, ok := list.Del(Uint64Key(k)); ok {
+					if v != k {
+						t.Errorf("delete failed: got %v for %v\n", v, k)
+					}
+					balance[k].Add(-1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := range balance {
+		_, ok := list.Get(Uint64Key(k))
+		if b := balance[k].Load(); (b == 1) != ok || b < 0 || b > 1 {
+			t.Errorf("contention failed: balance %v for %v, present %v\n", b, k, ok)
+		}
+	}
+}
+
+// TestConcurrentSkipListIterate iterates while other goroutines modify the
+// list. Keys which are never modified must always be seen, in order.
+func TestConcurrentSkipListIterate(t *testing.T) {
+	list := NewConcurrentSkipList()
+	for i := 0; i < NUM_NODES; i += 2 {
+		list.Set(Uint64Key(i), i)
+	}
+
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	for g := 0; g < NUM_GOROUTINES/2; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !done.Load() {
+				k := Uint64Key(2*rand.Intn(NUM_NODES/2) + 1)
+				if rand.Intn(2) == 0 {
+					list.Set(k, int(k))
+				} else {
+					list.Del(k)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		expected := 0
+		for k := range list.Ascend() {
+			if n := int(k.(Uint64Key)); n%2 == 0 {
+				if n != expected {
+					t.Fatalf("ascend failed: got %v, expected %v\n", n, expected)
+				}
+				expected += 2
+			} else if n < expected-1 {
+				t.Fatalf("ascend failed: got %v after %v\n", n, expected-2)
+			}
+		}
+		if expected != NUM_NODES {
+			t.Fatalf("ascend failed: stopped at %v\n", expected)
+		}
+
+		expected = NUM_NODES - 2
+		for k := range list.Descend() {
+			if n := int(k.(Uint64Key)); n%2 == 0 {
+				if n != expected {
+					t.Fatalf("descend failed: got %v, expected %v\n", n, expected)
+				}
+				expected -= 2
+			}
+		}
+		if expected != -2 {
+			t.Fatalf("descend failed: stopped at %v\n", expected)
+		}
+	}
+	done.Store(true)
+	wg.Wait()
+}
@@ -4,6 +4,7 @@ package tree
 // vim: ft=go
 
 import (
+	"math"
 	"testing"
 )
 
@@ -138,6 +139,403 @@ func testDel(t *testing.T, tree Tree) {
 	}
 }
 
+func testAscend(t *testing.T, tree Tree) {
+	for _, v := range tree.Ascend() {
+		t.Fatalf("ascend failed: got %v from empty tree\n", v)
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := tree.Set(Uint64Key(v), v)
+		if ok {
+			t.Fatalf("ascend failed: duplicate reported on set %v of %v\n", i, v)
+		}
+	}
+
+	i := 0
+	for k, v := range tree.Ascend() {
+		if k != Uint64Key(i) {
+			t.Fatalf("ascend failed: got key %v, expected %v\n", k, i)
+		}
+		if v != i {
+			t.Errorf("ascend failed: got %v, expected %v\n", v, i)
+		}
+		i++
+	}
+	if i != NUM_NODES {
+		t.Errorf("ascend failed: visited %v nodes, expected %v\n", i, NUM_NODES)
+	}
+
+	i = 0
+	for range tree.Ascend() {
+		i++
+		if i == NUM_NODES/2 {
+			break
+		}
+	}
+	if i != NUM_NODES/2 {
+		t.Errorf("ascend failed: break after %v nodes, expected %v\n", i, NUM_NODES/2)
+	}
+}
+
+func testDescend(t *testing.T, tree Tree) {
+	for _, v := range tree.Descend() {
+		t.Fatalf("descend failed: got %v from empty tree\n", v)
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := tree.Set(Uint64Key(v), v)
+		if ok {
+			t.Fatalf("descend failed: duplicate reported on set %v of %v\n", i, v)
+		}
+	}
+
+	i := NUM_NODES - 1
+	for k, v := range tree.Descend() {
+		if k != Uint64Key(i) {
+			t.Fatalf("descend failed: got key %v, expected %v\n", k, i)
+		}
+		if v != i {
+			t.Errorf("descend failed: got %v, expected %v\n", v, i)
+		}
+		i--
+	}
+	if i != -1 {
+		t.Errorf("descend failed: visited %v nodes, expected %v\n", NUM_NODES-1-i, NUM_NODES)
+	}
+
+	i = 0
+	for range tree.Descend() {
+		i++
+		if i == NUM_NODES/2 {
+			break
+		}
+	}
+	if i != NUM_NODES/2 {
+		t.Errorf("descend failed: break after %v nodes, expected %v\n", i, NUM_NODES/2)
+	}
+}
+
+func testRange(t *testing.T, tree Tree) {
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := tree.Set(Uint64Key(2*v), v)
+		if ok {
+			t.Fatalf("range failed: duplicate reported on set %v of %v\n", i, v)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		lo := testRand.Intn(2*NUM_NODES + 2)
+		hi := lo + testRand.Intn(2*NUM_NODES+2-lo)
+
+		// The first key in the range is the next even number.
+		v := (lo + 1) / 2
+		for k, ov := range tree.Range(Uint64Key(lo), Uint64Key(hi)) {
+			if k != Uint64Key(2*v) {
+				t.Fatalf("range failed: got key %v, expected %v\n", k, 2*v)
+			}
+			if ov != v {
+				t.Errorf("range failed: got %v, expected %v\n", ov, v)
+			}
+			v++
+		}
+		if expected := max((lo+1)/2, min((hi+1)/2, NUM_NODES)); v != expected {
+			t.Errorf("range failed: [%v, %v) ended at %v, expected %v\n", lo, hi, v, expected)
+		}
+	}
+
+	for k := range tree.Range(Uint64Key(10), Uint64Key(10)) {
+		t.Errorf("range failed: got key %v from empty range\n", k)
+	}
+	for k := range tree.Range(Uint64Key(10), Uint64Key(0)) {
+		t.Errorf("range failed: got key %v from reversed range\n", k)
+	}
+}
+
+func testMinMax(t *testing.T, tree Tree) {
+	if k, _, ok := tree.Min(); ok {
+		t.Errorf("min failed: got key %v from empty tree\n", k)
+	}
+	if k, _, ok := tree.Max(); ok {
+		t.Errorf("max failed: got key %v from empty tree\n", k)
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := tree.Set(Uint64Key(v+1), v+1)
+		if ok {
+			t.Fatalf("min failed: duplicate reported on set %v of %v\n", i, v+1)
+		}
+	}
+
+	if k, v, ok := tree.Min(); !ok || k != Uint64Key(1) || v != 1 {
+		t.Errorf("min failed: got %v, %v, %v, expected %v\n", k, v, ok, 1)
+	}
+	if k, v, ok := tree.Max(); !ok || k != Uint64Key(NUM_NODES) || v != NUM_NODES {
+		t.Errorf("max failed: got %v, %v, %v, expected %v\n", k, v, ok, NUM_NODES)
+	}
+}
+
+// testNearest checks one of the nearest key lookups against a tree containing
+// the even numbers less than 2 * NUM_NODES. The expected function returns the
+// expected key for an argument, or -1 if there should be none.
+func testNearest(t *testing.T, tree Tree, name string,
+	lookup func(Key) (Key, interface{}, bool), expected func(int) int) {
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := tree.Set(Uint64Key(2*v), 2*v)
+		if ok {
+			t.Fatalf("%s failed: duplicate reported on set %v of %v\n", name, i, 2*v)
+		}
+	}
+
+	for i := 0; i < 2*NUM_NODES+2; i++ {
+		e := expected(i)
+		k, v, ok := lookup(Uint64Key(i))
+		if e < 0 {
+			if ok {
+				t.Errorf("%s failed: got key %v for %v, expected none\n", name, k, i)
+			}
+		} else if !ok || k != Uint64Key(e) || v != e {
+			t.Errorf("%s failed: got %v, %v, %v for %v, expected %v\n", name, k, v, ok, i, e)
+		}
+	}
+}
+
+func testFloor(t *testing.T, tree Tree) {
+	testNearest(t, tree, "floor", tree.Floor, func(i int) int {
+		return min(i&^1, 2*NUM_NODES-2)
+	})
+}
+
+func testCeiling(t *testing.T, tree Tree) {
+	testNearest(t, tree, "ceiling", tree.Ceiling, func(i int) int {
+		if e := (i + 1) &^ 1; e < 2*NUM_NODES {
+			return e
+		}
+		return -1
+	})
+}
+
+func testPredecessor(t *testing.T, tree Tree) {
+	testNearest(t, tree, "predecessor", tree.Predecessor, func(i int) int {
+		return min((i-1)&^1, 2*NUM_NODES-2)
+	})
+}
+
+func testSuccessor(t *testing.T, tree Tree) {
+	testNearest(t, tree, "successor", tree.Successor, func(i int) int {
+		if e := (i + 2) &^ 1; e < 2*NUM_NODES {
+			return e
+		}
+		return -1
+	})
+}
+
+func testLen(t *testing.T, tree Tree) {
+	if n := tree.Len(); n != 0 {
+		t.Errorf("len failed: got %v for empty tree\n", n)
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		tree.Set(Uint64Key(v), v)
+		if n := tree.Len(); n != i+1 {
+			t.Fatalf("len failed: got %v after set, expected %v\n", n, i+1)
+		}
+	}
+
+	for _, v := range testRand.Perm(NUM_NODES) {
+		tree.Set(Uint64Key(v), -v)
+		if n := tree.Len(); n != NUM_NODES {
+			t.Fatalf("len failed: got %v after duplicate set, expected %v\n", n, NUM_NODES)
+		}
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		tree.Del(Uint64Key(v))
+		tree.Del(Uint64Key(v))
+		if n := tree.Len(); n != NUM_NODES-i-1 {
+			t.Fatalf("len failed: got %v after delete, expected %v\n", n, NUM_NODES-i-1)
+		}
+	}
+}
+
+func testRankSelect(t *testing.T, tree Tree) {
+	ost, ok := tree.(OrderStatisticTree)
+	if !ok {
+		t.Skip("not an OrderStatisticTree")
+	}
+
+	for i, v := range testRand.Perm(NUM_NODES) {
+		_, ok := ost.Set(Uint64Key(2*v), v)
+		if ok {
+			t.Fatalf("rank failed: duplicate reported on set %v of %v\n", i, v)
+		}
+	}
+
+	for i := 0; i < 2*NUM_NODES+2; i++ {
+		if r := ost.Rank(Uint64Key(i)); r != min((i+1)/2, NUM_NODES) {
+			t.Errorf("rank failed: got %v for %v, expected %v\n", r, i, min((i+1)/2, NUM_NODES))
+		}
+	}
+
+	for _, i := range testRand.Perm(NUM_NODES) {
+		k, v, ok := ost.Select(i)
+		if !ok || k != Uint64Key(2*i) || v != i {
+			t.Errorf("select failed: got %v, %v, %v for %v, expected %v\n", k, v, ok, i, 2*i)
+		}
+	}
+	for _, i := range []int{-1, NUM_NODES, NUM_NODES + 1} {
+		if k, _, ok := ost.Select(i); ok {
+			t.Errorf("select failed: got key %v for %v, expected none\n", k, i)
+		}
+	}
+
+	// Delete half of the keys and check that the ranks are maintained.
+	for _, v := range testRand.Perm(NUM_NODES)[:NUM_NODES/2] {
+		ost.Del(Uint64Key(2 * v))
+	}
+	var keys []Key
+	for k := range ost.Ascend() {
+		keys = append(keys, k)
+	}
+	for i, k := range keys {
+		if r := ost.Rank(k); r != i {
+			t.Errorf("rank failed: got %v for %v, expected %v\n", r, k, i)
+		}
+		if sk, _, ok := ost.Select(i); !ok || sk != k {
+			t.Errorf("select failed: got %v, %v for %v, expected %v\n", sk, ok, i, k)
+		}
+	}
+}
+
+// checkKeyOrder inserts keys, which must be in ascending order, into the tree
+// in random order, checks that the tree orders them the same way, and deletes
+// them again.
+func checkKeyOrder(t *testing.T, tree Tree, keys []Key) {
+	for i, j := range testRand.Perm(len(keys)) {
+		if _, ok := tree.Set(keys[j], j); ok {
+			t.Fatalf("set failed: duplicate reported on set %v of %v\n", i, keys[j])
+		}
+	}
+
+	i := 0
+	for k, v := range tree.Ascend() {
+		if i >= len(keys) || k.CompareTo(keys[i]) != 0 || v != i {
+			t.Fatalf("ascend failed: got %v, %v at %v\n", k, v, i)
+		}
+		i++
+	}
+	if i != len(keys) {
+		t.Fatalf("ascend failed: visited %v keys, expected %v\n", i, len(keys))
+	}
+
+	for _, j := range testRand.Perm(len(keys)) {
+		if v, ok := tree.Del(keys[j]); !ok || v != j {
+			t.Fatalf("delete failed: got %v, %v for %v\n", v, ok, keys[j])
+		}
+	}
+}
+
+func testKeyTypes(t *testing.T, tree Tree) {
+	switch tree.(type) {
+	case *trieTree, *keyAdapter[string], *keyAdapter[uint32], *keyAdapter[Key]:
+		t.Skip("tree only supports Uint64Key")
+	}
+
+	checkKeyOrder(t, tree, []Key{
+		Int64Key(math.MinInt64), Int64Key(-2), Int64Key(-1), Int64Key(0),
+		Int64Key(1), Int64Key(math.MaxInt64),
+	})
+	checkKeyOrder(t, tree, []Key{
+		StringKey(""), StringKey("A"), StringKey("a"), StringKey("ab"),
+		StringKey("b"), StringKey("\xff"),
+	})
+	checkKeyOrder(t, tree, []Key{
+		BytesKey(nil), BytesKey{0}, BytesKey{0, 0}, BytesKey{0, 1},
+		BytesKey{1}, BytesKey{0xff},
+	})
+	checkKeyOrder(t, tree, []Key{
+		Float64Key(math.NaN()), Float64Key(math.Inf(-1)), Float64Key(-1),
+		Float64Key(math.Copysign(0, -1)), Float64Key(0),
+		Float64Key(math.SmallestNonzeroFloat64), Float64Key(1),
+		Float64Key(math.Inf(1)),
+	})
+	checkKeyOrder(t, tree, []Key{
+		TupleKey{}, TupleKey{Int64Key(-1)},
+		TupleKey{Int64Key(-1), StringKey("a")},
+		TupleKey{Int64Key(-1), StringKey("b")}, TupleKey{Int64Key(0)},
+		TupleKey{Int64Key(0), StringKey("")},
+		TupleKey{Int64Key(0), StringKey(""), Float64Key(0)},
+		TupleKey{Int64Key(1)},
+	})
+
+	// Equal keys replace each other.
+	tree.Set(Float64Key(math.NaN()), 1)
+	if v, ok := tree.Set(Float64Key(-math.NaN()), 2); !ok || v != 1 {
+		t.Errorf("set failed: got %v, %v for NaN, expected 1\n", v, ok)
+	}
+	tree.Del(Float64Key(math.NaN()))
+
+	tree.Set(BytesKey(nil), 1)
+	if v, ok := tree.Get(BytesKey{}); !ok || v != 1 {
+		t.Errorf("get failed: got %v, %v for empty BytesKey, expected 1\n", v, ok)
+	}
+}
+
+func testPrefix(t *testing.T, tree Tree) {
+	tt, ok := tree.(*trieTree)
+	if !ok {
+		t.Skip("not a trie")
+	}
+	trie := tt.trie
+
+	// Spread the keys over 16 prefixes of 4 bits.
+	for _, v := range testRand.Perm(NUM_NODES) {
+		trie.Set(uint64(v%16)<<60|uint64(v/16), v)
+	}
+
+	for p := uint64(0); p < 16; p++ {
+		expected := (NUM_NODES - int(p) + 15) / 16
+		if n := trie.CountPrefix(p, 4); n != expected {
+			t.Errorf("count failed: got %v for %v, expected %v\n", n, p, expected)
+		}
+
+		i := 0
+		trie.WalkPrefix(p, 4, func(k uint64, v interface{}) bool {
+			if k != p<<60|uint64(i) || v != 16*i+int(p) {
+				t.Fatalf("walk failed: got %v, %v for %v, expected %v\n", k, v, p, i)
+			}
+			i++
+			return true
+		})
+		if i != expected {
+			t.Errorf("walk failed: visited %v keys for %v, expected %v\n", i, p, expected)
+		}
+
+		// Longer prefixes narrow the walk down further.
+		if n := trie.CountPrefix(p<<60>>56, 8); n != expected {
+			t.Errorf("count failed: got %v for %v/8, expected %v\n", n, p<<4, expected)
+		}
+		if n := trie.CountPrefix(p<<60|1, 64); n != 1 {
+			t.Errorf("count failed: got %v for %v/64, expected 1\n", n, p<<60|1)
+		}
+	}
+
+	if n := trie.CountPrefix(0, 0); n != NUM_NODES {
+		t.Errorf("count failed: got %v for empty prefix, expected %v\n", n, NUM_NODES)
+	}
+	if n := trie.CountPrefix(16, 4); n != 0 {
+		t.Errorf("count failed: got %v for invalid prefix, expected 0\n", n)
+	}
+
+	n := 0
+	trie.WalkPrefix(3, 2, func(uint64, interface{}) bool {
+		n++
+		return n < 10
+	})
+	if n != 10 {
+		t.Errorf("walk failed: visited %v keys after stopping, expected 10\n", n)
+	}
+}
+
 // Binary search tree.
 func TestBSTDelMissing(t *testing.T) {
 	testDelMissing(t, NewBST())
@@ -154,6 +552,42 @@ func TestBSTGetMissing(t *testing.T) {
 func TestBSTSetUnique(t *testing.T) {
 	testSetUnique(t, NewBST())
 }
+func TestBSTAscend(t *testing.T) {
+	testAscend(t, NewBST())
+}
+func TestBSTDescend(t *testing.T) {
+	testDescend(t, NewBST())
+}
+func TestBSTRange(t *testing.T) {
+	testRange(t, NewBST())
+}
+func TestBSTMinMax(t *testing.T) {
+	testMinMax(t, NewBST())
+}
+func TestBSTFloor(t *testing.T) {
+	testFloor(t, NewBST())
+}
+func TestBSTCeiling(t *testing.T) {
+	testCeiling(t, NewBST())
+}
+func TestBSTPredecessor(t *testing.T) {
+	testPredecessor(t, NewBST())
+}
+func TestBSTSuccessor(t *testing.T) {
+	testSuccessor(t, NewBST())
+}
+func TestBSTLen(t *testing.T) {
+	testLen(t, NewBST())
+}
+func TestBSTRankSelect(t *testing.T) {
+	testRankSelect(t, NewBST())
+}
+func TestBSTKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewBST())
+}
+func TestBSTPrefix(t *testing.T) {
+	testPrefix(t, NewBST())
+}
 
 // Splay tree.
 func TestSplayDelMissing(t *testing.T) {
@@ -171,54 +605,1417 @@ func TestSplayGetMissing(t *testing.T) {
 func TestSplaySetUnique(t *testing.T) {
 	testSetUnique(t, NewSplay())
 }
+func TestSplayAscend(t *testing.T) {
+	testAscend(t, NewSplay())
+}
+func TestSplayDescend(t *testing.T) {
+	testDescend(t, NewSplay())
+}
+func TestSplayRange(t *testing.T) {
+	testRange(t, NewSplay())
+}
+func TestSplayMinMax(t *testing.T) {
+	testMinMax(t, NewSplay())
+}
+func TestSplayFloor(t *testing.T) {
+	testFloor(t, NewSplay())
+}
+func TestSplayCeiling(t *testing.T) {
+	testCeiling(t, NewSplay())
+}
+func TestSplayPredecessor(t *testing.T) {
+	testPredecessor(t, NewSplay())
+}
+func TestSplaySuccessor(t *testing.T) {
+	testSuccessor(t, NewSplay())
+}
+func TestSplayLen(t *testing.T) {
+	testLen(t, NewSplay())
+}
+func TestSplayRankSelect(t *testing.T) {
+	testRankSelect(t, NewSplay())
+}
+func TestSplayKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewSplay())
+}
+func TestSplayPrefix(t *testing.T) {
+	testPrefix(t, NewSplay())
+}
 
-// Simple trie.
-func TestSimpleTrieDelMissing(t *testing.T) {
-	testDelMissing(t, NewTreeFromTrie(NewBinaryTrie()))
+// Order statistic binary search tree.
+func TestOrderStatisticBSTDelMissing(t *testing.T) {
+	testDelMissing(t, NewOrderStatisticBST())
 }
-func TestSimpleTrieDel(t *testing.T) {
-	testDel(t, NewTreeFromTrie(NewBinaryTrie()))
+func TestOrderStatisticBSTDel(t *testing.T) {
+	testDel(t, NewOrderStatisticBST())
 }
-func TestSimpleTrieSetDuplicates(t *testing.T) {
-	testSetDuplicates(t, NewTreeFromTrie(NewBinaryTrie()))
+func TestOrderStatisticBSTSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewOrderStatisticBST())
 }
-func TestSimpleTrieGetMissing(t *testing.T) {
-	testGetMissing(t, NewTreeFromTrie(NewBinaryTrie()))
+func TestOrderStatisticBSTGetMissing(t *testing.T) {
+	testGetMissing(t, NewOrderStatisticBST())
 }
-func TestSimpleTrieSetUnique(t *testing.T) {
-	testSetUnique(t, NewTreeFromTrie(NewBinaryTrie()))
+func TestOrderStatisticBSTSetUnique(t *testing.T) {
+	testSetUnique(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTAscend(t *testing.T) {
+	testAscend(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTDescend(t *testing.T) {
+	testDescend(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTRange(t *testing.T) {
+	testRange(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTMinMax(t *testing.T) {
+	testMinMax(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTFloor(t *testing.T) {
+	testFloor(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTCeiling(t *testing.T) {
+	testCeiling(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTPredecessor(t *testing.T) {
+	testPredecessor(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTSuccessor(t *testing.T) {
+	testSuccessor(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTLen(t *testing.T) {
+	testLen(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTRankSelect(t *testing.T) {
+	testRankSelect(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewOrderStatisticBST())
+}
+func TestOrderStatisticBSTPrefix(t *testing.T) {
+	testPrefix(t, NewOrderStatisticBST())
 }
 
-// CLZ trie.
-func TestCLZTrieDelMissing(t *testing.T) {
-	testDelMissing(t, NewTreeFromTrie(NewCLZTrie()))
+// Order statistic splay tree.
+func TestOrderStatisticSplayDelMissing(t *testing.T) {
+	testDelMissing(t, NewOrderStatisticSplay())
 }
-func TestCLZTrieDel(t *testing.T) {
-	testDel(t, NewTreeFromTrie(NewCLZTrie()))
+func TestOrderStatisticSplayDel(t *testing.T) {
+	testDel(t, NewOrderStatisticSplay())
 }
-func TestCLZTrieSetDuplicates(t *testing.T) {
-	testSetDuplicates(t, NewTreeFromTrie(NewCLZTrie()))
+func TestOrderStatisticSplaySetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewOrderStatisticSplay())
 }
-func TestCLZTrieGetMissing(t *testing.T) {
-	testGetMissing(t, NewTreeFromTrie(NewCLZTrie()))
+func TestOrderStatisticSplayGetMissing(t *testing.T) {
+	testGetMissing(t, NewOrderStatisticSplay())
 }
-func TestCLZTrieSetUnique(t *testing.T) {
-	testSetUnique(t, NewTreeFromTrie(NewCLZTrie()))
+func TestOrderStatisticSplaySetUnique(t *testing.T) {
+	testSetUnique(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayAscend(t *testing.T) {
+	testAscend(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayDescend(t *testing.T) {
+	testDescend(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayRange(t *testing.T) {
+	testRange(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayMinMax(t *testing.T) {
+	testMinMax(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayFloor(t *testing.T) {
+	testFloor(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayCeiling(t *testing.T) {
+	testCeiling(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayPredecessor(t *testing.T) {
+	testPredecessor(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplaySuccessor(t *testing.T) {
+	testSuccessor(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayLen(t *testing.T) {
+	testLen(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayRankSelect(t *testing.T) {
+	testRankSelect(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewOrderStatisticSplay())
+}
+func TestOrderStatisticSplayPrefix(t *testing.T) {
+	testPrefix(t, NewOrderStatisticSplay())
 }
 
-// Radix trie.
-func TestRadixTrieDelMissing(t *testing.T) {
-	testDelMissing(t, NewTreeFromTrie(NewRadixTrie()))
+// AVL tree.
+func TestAVLDelMissing(t *testing.T) {
+	testDelMissing(t, NewAVL())
 }
-func TestRadixTrieDel(t *testing.T) {
-	testDel(t, NewTreeFromTrie(NewRadixTrie()))
+func TestAVLDel(t *testing.T) {
+	testDel(t, NewAVL())
 }
-func TestRadixTrieSetDuplicates(t *testing.T) {
-	testSetDuplicates(t, NewTreeFromTrie(NewRadixTrie()))
+func TestAVLSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewAVL())
 }
-func TestRadixTrieGetMissing(t *testing.T) {
-	testGetMissing(t, NewTreeFromTrie(NewRadixTrie()))
+func TestAVLGetMissing(t *testing.T) {
+	testGetMissing(t, NewAVL())
 }
-func TestRadixTrieSetUnique(t *testing.T) {
-	testSetUnique(t, NewTreeFromTrie(NewRadixTrie()))
+func TestAVLSetUnique(t *testing.T) {
+	testSetUnique(t, NewAVL())
+}
+func TestAVLAscend(t *testing.T) {
+	testAscend(t, NewAVL())
+}
+func TestAVLDescend(t *testing.T) {
+	testDescend(t, NewAVL())
+}
+func TestAVLRange(t *testing.T) {
+	testRange(t, NewAVL())
+}
+func TestAVLMinMax(t *testing.T) {
+	testMinMax(t, NewAVL())
+}
+func TestAVLFloor(t *testing.T) {
+	testFloor(t, NewAVL())
+}
+func TestAVLCeiling(t *testing.T) {
+	testCeiling(t, NewAVL())
+}
+func TestAVLPredecessor(t *testing.T) {
+	testPredecessor(t, NewAVL())
+}
+func TestAVLSuccessor(t *testing.T) {
+	testSuccessor(t, NewAVL())
+}
+func TestAVLLen(t *testing.T) {
+	testLen(t, NewAVL())
+}
+func TestAVLRankSelect(t *testing.T) {
+	testRankSelect(t, NewAVL())
+}
+func TestAVLKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewAVL())
+}
+func TestAVLPrefix(t *testing.T) {
+	testPrefix(t, NewAVL())
+}
+
+// Red-black tree.
+func TestRedBlackDelMissing(t *testing.T) {
+	testDelMissing(t, NewRedBlack())
+}
+func TestRedBlackDel(t *testing.T) {
+	testDel(t, NewRedBlack())
+}
+func TestRedBlackSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewRedBlack())
+}
+func TestRedBlackGetMissing(t *testing.T) {
+	testGetMissing(t, NewRedBlack())
+}
+func TestRedBlackSetUnique(t *testing.T) {
+	testSetUnique(t, NewRedBlack())
+}
+func TestRedBlackAscend(t *testing.T) {
+	testAscend(t, NewRedBlack())
+}
+func TestRedBlackDescend(t *testing.T) {
+	testDescend(t, NewRedBlack())
+}
+func TestRedBlackRange(t *testing.T) {
+	testRange(t, NewRedBlack())
+}
+func TestRedBlackMinMax(t *testing.T) {
+	testMinMax(t, NewRedBlack())
+}
+func TestRedBlackFloor(t *testing.T) {
+	testFloor(t, NewRedBlack())
+}
+func TestRedBlackCeiling(t *testing.T) {
+	testCeiling(t, NewRedBlack())
+}
+func TestRedBlackPredecessor(t *testing.T) {
+	testPredecessor(t, NewRedBlack())
+}
+func TestRedBlackSuccessor(t *testing.T) {
+	testSuccessor(t, NewRedBlack())
+}
+func TestRedBlackLen(t *testing.T) {
+	testLen(t, NewRedBlack())
+}
+func TestRedBlackRankSelect(t *testing.T) {
+	testRankSelect(t, NewRedBlack())
+}
+func TestRedBlackKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewRedBlack())
+}
+func TestRedBlackPrefix(t *testing.T) {
+	testPrefix(t, NewRedBlack())
+}
+
+// Treap.
+func TestTreapDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreap())
+}
+func TestTreapDel(t *testing.T) {
+	testDel(t, NewTreap())
+}
+func TestTreapSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreap())
+}
+func TestTreapGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreap())
+}
+func TestTreapSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreap())
+}
+func TestTreapAscend(t *testing.T) {
+	testAscend(t, NewTreap())
+}
+func TestTreapDescend(t *testing.T) {
+	testDescend(t, NewTreap())
+}
+func TestTreapRange(t *testing.T) {
+	testRange(t, NewTreap())
+}
+func TestTreapMinMax(t *testing.T) {
+	testMinMax(t, NewTreap())
+}
+func TestTreapFloor(t *testing.T) {
+	testFloor(t, NewTreap())
+}
+func TestTreapCeiling(t *testing.T) {
+	testCeiling(t, NewTreap())
+}
+func TestTreapPredecessor(t *testing.T) {
+	testPredecessor(t, NewTreap())
+}
+func TestTreapSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreap())
+}
+func TestTreapLen(t *testing.T) {
+	testLen(t, NewTreap())
+}
+func TestTreapRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreap())
+}
+func TestTreapKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreap())
+}
+func TestTreapPrefix(t *testing.T) {
+	testPrefix(t, NewTreap())
+}
+
+// B-tree of degree 2.
+func TestBTree2DelMissing(t *testing.T) {
+	testDelMissing(t, NewBTree(2))
+}
+func TestBTree2Del(t *testing.T) {
+	testDel(t, NewBTree(2))
+}
+func TestBTree2SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewBTree(2))
+}
+func TestBTree2GetMissing(t *testing.T) {
+	testGetMissing(t, NewBTree(2))
+}
+func TestBTree2SetUnique(t *testing.T) {
+	testSetUnique(t, NewBTree(2))
+}
+func TestBTree2Ascend(t *testing.T) {
+	testAscend(t, NewBTree(2))
+}
+func TestBTree2Descend(t *testing.T) {
+	testDescend(t, NewBTree(2))
+}
+func TestBTree2Range(t *testing.T) {
+	testRange(t, NewBTree(2))
+}
+func TestBTree2MinMax(t *testing.T) {
+	testMinMax(t, NewBTree(2))
+}
+func TestBTree2Floor(t *testing.T) {
+	testFloor(t, NewBTree(2))
+}
+func TestBTree2Ceiling(t *testing.T) {
+	testCeiling(t, NewBTree(2))
+}
+func TestBTree2Predecessor(t *testing.T) {
+	testPredecessor(t, NewBTree(2))
+}
+func TestBTree2Successor(t *testing.T) {
+	testSuccessor(t, NewBTree(2))
+}
+func TestBTree2Len(t *testing.T) {
+	testLen(t, NewBTree(2))
+}
+func TestBTree2RankSelect(t *testing.T) {
+	testRankSelect(t, NewBTree(2))
+}
+func TestBTree2KeyTypes(t *testing.T) {
+	testKeyTypes(t, NewBTree(2))
+}
+func TestBTree2Prefix(t *testing.T) {
+	testPrefix(t, NewBTree(2))
+}
+
+// B-tree.
+func TestBTreeDelMissing(t *testing.T) {
+	testDelMissing(t, NewBTree(16))
+}
+func TestBTreeDel(t *testing.T) {
+	testDel(t, NewBTree(16))
+}
+func TestBTreeSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewBTree(16))
+}
+func TestBTreeGetMissing(t *testing.T) {
+	testGetMissing(t, NewBTree(16))
+}
+func TestBTreeSetUnique(t *testing.T) {
+	testSetUnique(t, NewBTree(16))
+}
+func TestBTreeAscend(t *testing.T) {
+	testAscend(t, NewBTree(16))
+}
+func TestBTreeDescend(t *testing.T) {
+	testDescend(t, NewBTree(16))
+}
+func TestBTreeRange(t *testing.T) {
+	testRange(t, NewBTree(16))
+}
+func TestBTreeMinMax(t *testing.T) {
+	testMinMax(t, NewBTree(16))
+}
+func TestBTreeFloor(t *testing.T) {
+	testFloor(t, NewBTree(16))
+}
+func TestBTreeCeiling(t *testing.T) {
+	testCeiling(t, NewBTree(16))
+}
+func TestBTreePredecessor(t *testing.T) {
+	testPredecessor(t, NewBTree(16))
+}
+func TestBTreeSuccessor(t *testing.T) {
+	testSuccessor(t, NewBTree(16))
+}
+func TestBTreeLen(t *testing.T) {
+	testLen(t, NewBTree(16))
+}
+func TestBTreeRankSelect(t *testing.T) {
+	testRankSelect(t, NewBTree(16))
+}
+func TestBTreeKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewBTree(16))
+}
+func TestBTreePrefix(t *testing.T) {
+	testPrefix(t, NewBTree(16))
+}
+
+// Skip list.
+func TestSkipListDelMissing(t *testing.T) {
+	testDelMissing(t, NewSkipList())
+}
+func TestSkipListDel(t *testing.T) {
+	testDel(t, NewSkipList())
+}
+func TestSkipListSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewSkipList())
+}
+func TestSkipListGetMissing(t *testing.T) {
+	testGetMissing(t, NewSkipList())
+}
+func TestSkipListSetUnique(t *testing.T) {
+	testSetUnique(t, NewSkipList())
+}
+func TestSkipListAscend(t *testing.T) {
+	testAscend(t, NewSkipList())
+}
+func TestSkipListDescend(t *testing.T) {
+	testDescend(t, NewSkipList())
+}
+func TestSkipListRange(t *testing.T) {
+	testRange(t, NewSkipList())
+}
+func TestSkipListMinMax(t *testing.T) {
+	testMinMax(t, NewSkipList())
+}
+func TestSkipListFloor(t *testing.T) {
+	testFloor(t, NewSkipList())
+}
+func TestSkipListCeiling(t *testing.T) {
+	testCeiling(t, NewSkipList())
+}
+func TestSkipListPredecessor(t *testing.T) {
+	testPredecessor(t, NewSkipList())
+}
+func TestSkipListSuccessor(t *testing.T) {
+	testSuccessor(t, NewSkipList())
+}
+func TestSkipListLen(t *testing.T) {
+	testLen(t, NewSkipList())
+}
+func TestSkipListRankSelect(t *testing.T) {
+	testRankSelect(t, NewSkipList())
+}
+func TestSkipListKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewSkipList())
+}
+func TestSkipListPrefix(t *testing.T) {
+	testPrefix(t, NewSkipList())
+}
+
+// Concurrent skip list.
+func TestConcurrentSkipListDelMissing(t *testing.T) {
+	testDelMissing(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListDel(t *testing.T) {
+	testDel(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListGetMissing(t *testing.T) {
+	testGetMissing(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListSetUnique(t *testing.T) {
+	testSetUnique(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListAscend(t *testing.T) {
+	testAscend(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListDescend(t *testing.T) {
+	testDescend(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListRange(t *testing.T) {
+	testRange(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListMinMax(t *testing.T) {
+	testMinMax(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListFloor(t *testing.T) {
+	testFloor(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListCeiling(t *testing.T) {
+	testCeiling(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListPredecessor(t *testing.T) {
+	testPredecessor(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListSuccessor(t *testing.T) {
+	testSuccessor(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListLen(t *testing.T) {
+	testLen(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListRankSelect(t *testing.T) {
+	testRankSelect(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewConcurrentSkipList())
+}
+func TestConcurrentSkipListPrefix(t *testing.T) {
+	testPrefix(t, NewConcurrentSkipList())
+}
+
+// Simple trie.
+func TestSimpleTrieDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTriePredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTrieKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+func TestSimpleTriePrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewBinaryTrie()))
+}
+
+// CLZ trie.
+func TestCLZTrieDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTriePredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTrieKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewCLZTrie()))
+}
+func TestCLZTriePrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewCLZTrie()))
+}
+
+// Radix trie.
+func TestRadixTrieDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTriePredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTrieKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewRadixTrie()))
+}
+func TestRadixTriePrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewRadixTrie()))
+}
+
+// Radix trie of width 1.
+func TestRadixTrie1DelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Del(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1GetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1SetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Ascend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Descend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Range(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1MinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Floor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Ceiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Predecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Successor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Len(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1RankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1KeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+func TestRadixTrie1Prefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewRadixTrieWidth(1)))
+}
+
+// Radix trie of width 3.
+func TestRadixTrie3DelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Del(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3GetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3SetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Ascend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Descend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Range(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3MinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Floor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Ceiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Predecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Successor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Len(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3RankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3KeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+func TestRadixTrie3Prefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewRadixTrieWidth(3)))
+}
+
+// Radix trie of width 8.
+func TestRadixTrie8DelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Del(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8GetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8SetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Ascend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Descend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Range(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8MinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Floor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Ceiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Predecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Successor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Len(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8RankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8KeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+func TestRadixTrie8Prefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewRadixTrieWidth(8)))
+}
+
+// Concurrent radix trie.
+func TestConcurrentRadixTrieDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTriePredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTrieKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+func TestConcurrentRadixTriePrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewConcurrentRadixTrie()))
+}
+
+// Adaptive radix trie.
+func TestARTDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTPredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+func TestARTPrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewAdaptiveRadixTrie()))
+}
+
+// 32-bit binary trie.
+func TestBinaryTrie32DelMissing(t *testing.T) {
+	testDelMissing(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Del(t *testing.T) {
+	testDel(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32GetMissing(t *testing.T) {
+	testGetMissing(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32SetUnique(t *testing.T) {
+	testSetUnique(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Ascend(t *testing.T) {
+	testAscend(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Descend(t *testing.T) {
+	testDescend(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Range(t *testing.T) {
+	testRange(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32MinMax(t *testing.T) {
+	testMinMax(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Floor(t *testing.T) {
+	testFloor(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Ceiling(t *testing.T) {
+	testCeiling(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Predecessor(t *testing.T) {
+	testPredecessor(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Successor(t *testing.T) {
+	testSuccessor(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Len(t *testing.T) {
+	testLen(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32RankSelect(t *testing.T) {
+	testRankSelect(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32KeyTypes(t *testing.T) {
+	testKeyTypes(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+func TestBinaryTrie32Prefix(t *testing.T) {
+	testPrefix(t, newTrie32Adapter(NewBinaryTrie32()))
+}
+
+// 32-bit CLZ trie.
+func TestCLZTrie32DelMissing(t *testing.T) {
+	testDelMissing(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Del(t *testing.T) {
+	testDel(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32GetMissing(t *testing.T) {
+	testGetMissing(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32SetUnique(t *testing.T) {
+	testSetUnique(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Ascend(t *testing.T) {
+	testAscend(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Descend(t *testing.T) {
+	testDescend(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Range(t *testing.T) {
+	testRange(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32MinMax(t *testing.T) {
+	testMinMax(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Floor(t *testing.T) {
+	testFloor(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Ceiling(t *testing.T) {
+	testCeiling(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Predecessor(t *testing.T) {
+	testPredecessor(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Successor(t *testing.T) {
+	testSuccessor(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Len(t *testing.T) {
+	testLen(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32RankSelect(t *testing.T) {
+	testRankSelect(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32KeyTypes(t *testing.T) {
+	testKeyTypes(t, newTrie32Adapter(NewCLZTrie32()))
+}
+func TestCLZTrie32Prefix(t *testing.T) {
+	testPrefix(t, newTrie32Adapter(NewCLZTrie32()))
+}
+
+// 32-bit radix trie.
+func TestRadixTrie32DelMissing(t *testing.T) {
+	testDelMissing(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Del(t *testing.T) {
+	testDel(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32GetMissing(t *testing.T) {
+	testGetMissing(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32SetUnique(t *testing.T) {
+	testSetUnique(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Ascend(t *testing.T) {
+	testAscend(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Descend(t *testing.T) {
+	testDescend(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Range(t *testing.T) {
+	testRange(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32MinMax(t *testing.T) {
+	testMinMax(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Floor(t *testing.T) {
+	testFloor(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Ceiling(t *testing.T) {
+	testCeiling(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Predecessor(t *testing.T) {
+	testPredecessor(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Successor(t *testing.T) {
+	testSuccessor(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Len(t *testing.T) {
+	testLen(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32RankSelect(t *testing.T) {
+	testRankSelect(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32KeyTypes(t *testing.T) {
+	testKeyTypes(t, newTrie32Adapter(NewRadixTrie32()))
+}
+func TestRadixTrie32Prefix(t *testing.T) {
+	testPrefix(t, newTrie32Adapter(NewRadixTrie32()))
+}
+
+// 128-bit binary trie.
+func TestBinaryTrie128DelMissing(t *testing.T) {
+	testDelMissing(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Del(t *testing.T) {
+	testDel(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128GetMissing(t *testing.T) {
+	testGetMissing(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128SetUnique(t *testing.T) {
+	testSetUnique(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Ascend(t *testing.T) {
+	testAscend(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Descend(t *testing.T) {
+	testDescend(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Range(t *testing.T) {
+	testRange(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128MinMax(t *testing.T) {
+	testMinMax(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Floor(t *testing.T) {
+	testFloor(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Ceiling(t *testing.T) {
+	testCeiling(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Predecessor(t *testing.T) {
+	testPredecessor(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Successor(t *testing.T) {
+	testSuccessor(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Len(t *testing.T) {
+	testLen(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128RankSelect(t *testing.T) {
+	testRankSelect(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128KeyTypes(t *testing.T) {
+	testKeyTypes(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+func TestBinaryTrie128Prefix(t *testing.T) {
+	testPrefix(t, newTrie128Adapter(NewBinaryTrie128()))
+}
+
+// 128-bit radix trie.
+func TestRadixTrie128DelMissing(t *testing.T) {
+	testDelMissing(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Del(t *testing.T) {
+	testDel(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128SetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128GetMissing(t *testing.T) {
+	testGetMissing(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128SetUnique(t *testing.T) {
+	testSetUnique(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Ascend(t *testing.T) {
+	testAscend(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Descend(t *testing.T) {
+	testDescend(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Range(t *testing.T) {
+	testRange(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128MinMax(t *testing.T) {
+	testMinMax(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Floor(t *testing.T) {
+	testFloor(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Ceiling(t *testing.T) {
+	testCeiling(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Predecessor(t *testing.T) {
+	testPredecessor(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Successor(t *testing.T) {
+	testSuccessor(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Len(t *testing.T) {
+	testLen(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128RankSelect(t *testing.T) {
+	testRankSelect(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128KeyTypes(t *testing.T) {
+	testKeyTypes(t, newTrie128Adapter(NewRadixTrie128()))
+}
+func TestRadixTrie128Prefix(t *testing.T) {
+	testPrefix(t, newTrie128Adapter(NewRadixTrie128()))
+}
+
+// String radix tree.
+func TestStringRadixTreeDelMissing(t *testing.T) {
+	testDelMissing(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeDel(t *testing.T) {
+	testDel(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeGetMissing(t *testing.T) {
+	testGetMissing(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeSetUnique(t *testing.T) {
+	testSetUnique(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeAscend(t *testing.T) {
+	testAscend(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeDescend(t *testing.T) {
+	testDescend(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeRange(t *testing.T) {
+	testRange(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeMinMax(t *testing.T) {
+	testMinMax(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeFloor(t *testing.T) {
+	testFloor(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeCeiling(t *testing.T) {
+	testCeiling(t, newStringTreeAdapter())
+}
+func TestStringRadixTreePredecessor(t *testing.T) {
+	testPredecessor(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeSuccessor(t *testing.T) {
+	testSuccessor(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeLen(t *testing.T) {
+	testLen(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeRankSelect(t *testing.T) {
+	testRankSelect(t, newStringTreeAdapter())
+}
+func TestStringRadixTreeKeyTypes(t *testing.T) {
+	testKeyTypes(t, newStringTreeAdapter())
+}
+func TestStringRadixTreePrefix(t *testing.T) {
+	testPrefix(t, newStringTreeAdapter())
+}
+
+// Synchronized splay tree.
+func TestSynchronizedSplayDelMissing(t *testing.T) {
+	testDelMissing(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayDel(t *testing.T) {
+	testDel(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplaySetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayGetMissing(t *testing.T) {
+	testGetMissing(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplaySetUnique(t *testing.T) {
+	testSetUnique(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayAscend(t *testing.T) {
+	testAscend(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayDescend(t *testing.T) {
+	testDescend(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayRange(t *testing.T) {
+	testRange(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayMinMax(t *testing.T) {
+	testMinMax(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayFloor(t *testing.T) {
+	testFloor(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayCeiling(t *testing.T) {
+	testCeiling(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayPredecessor(t *testing.T) {
+	testPredecessor(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplaySuccessor(t *testing.T) {
+	testSuccessor(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayLen(t *testing.T) {
+	testLen(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayRankSelect(t *testing.T) {
+	testRankSelect(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewSynchronized(NewSplay()))
+}
+func TestSynchronizedSplayPrefix(t *testing.T) {
+	testPrefix(t, NewSynchronized(NewSplay()))
+}
+
+// Synchronized red-black tree.
+func TestSynchronizedRedBlackDelMissing(t *testing.T) {
+	testDelMissing(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackDel(t *testing.T) {
+	testDel(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackGetMissing(t *testing.T) {
+	testGetMissing(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackSetUnique(t *testing.T) {
+	testSetUnique(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackAscend(t *testing.T) {
+	testAscend(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackDescend(t *testing.T) {
+	testDescend(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackRange(t *testing.T) {
+	testRange(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackMinMax(t *testing.T) {
+	testMinMax(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackFloor(t *testing.T) {
+	testFloor(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackCeiling(t *testing.T) {
+	testCeiling(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackPredecessor(t *testing.T) {
+	testPredecessor(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackSuccessor(t *testing.T) {
+	testSuccessor(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackLen(t *testing.T) {
+	testLen(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackRankSelect(t *testing.T) {
+	testRankSelect(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewSynchronized(NewRedBlack()))
+}
+func TestSynchronizedRedBlackPrefix(t *testing.T) {
+	testPrefix(t, NewSynchronized(NewRedBlack()))
+}
+
+// Synchronized radix trie.
+func TestSynchronizedRadixTrieDelMissing(t *testing.T) {
+	testDelMissing(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieDel(t *testing.T) {
+	testDel(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieSetDuplicates(t *testing.T) {
+	testSetDuplicates(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieGetMissing(t *testing.T) {
+	testGetMissing(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieSetUnique(t *testing.T) {
+	testSetUnique(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieAscend(t *testing.T) {
+	testAscend(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieDescend(t *testing.T) {
+	testDescend(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieRange(t *testing.T) {
+	testRange(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieMinMax(t *testing.T) {
+	testMinMax(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieFloor(t *testing.T) {
+	testFloor(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieCeiling(t *testing.T) {
+	testCeiling(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTriePredecessor(t *testing.T) {
+	testPredecessor(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieSuccessor(t *testing.T) {
+	testSuccessor(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieLen(t *testing.T) {
+	testLen(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieRankSelect(t *testing.T) {
+	testRankSelect(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTrieKeyTypes(t *testing.T) {
+	testKeyTypes(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+func TestSynchronizedRadixTriePrefix(t *testing.T) {
+	testPrefix(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
 }
@@ -0,0 +1,72 @@
+package tree
+
+import (
+	"testing"
+)
+
+// Key type which does not implement Key.
+type funcTestKey struct {
+	major, minor int
+}
+
+// compareFuncTestKeys orders funcTestKeys by major and then minor.
+func compareFuncTestKeys(a, b interface{}) int {
+	x, y := a.(funcTestKey), b.(funcTestKey)
+	if x.major != y.major {
+		return x.major - y.major
+	}
+	return x.minor - y.minor
+}
+
+func testFunc(t *testing.T, tree TreeOf[interface{}, interface{}]) {
+	for _, v := range testRand.Perm(NUM_NODES) {
+		if _, ok := tree.Set(funcTestKey{v / 100, v % 100}, v); ok {
+			t.Fatalf("set failed: duplicate reported for %v\n", v)
+		}
+	}
+
+	i := 0
+	for k, v := range tree.Ascend() {
+		if k != (funcTestKey{i / 100, i % 100}) || v != i {
+			t.Fatalf("ascend failed: got %v, %v, expected %v\n", k, v, i)
+		}
+		i++
+	}
+	if i != NUM_NODES {
+		t.Errorf("ascend failed: visited %v nodes, expected %v\n", i, NUM_NODES)
+	}
+
+	for _, v := range testRand.Perm(NUM_NODES) {
+		if ov, ok := tree.Del(funcTestKey{v / 100, v % 100}); !ok || ov != v {
+			t.Fatalf("delete failed: got %v, %v for %v\n", ov, ok, v)
+		}
+	}
+}
+
+func TestFuncBST(t *testing.T) {
+	testFunc(t, NewBSTFunc(compareFuncTestKeys))
+}
+
+func TestFuncSplay(t *testing.T) {
+	testFunc(t, NewSplayFunc(compareFuncTestKeys))
+}
+
+func TestFuncAVL(t *testing.T) {
+	testFunc(t, NewAVLFunc(compareFuncTestKeys))
+}
+
+func TestFuncRedBlack(t *testing.T) {
+	testFunc(t, NewRedBlackFunc(compareFuncTestKeys))
+}
+
+func TestFuncTreap(t *testing.T) {
+	testFunc(t, NewTreapFunc(compareFuncTestKeys))
+}
+
+func TestFuncBTree(t *testing.T) {
+	testFunc(t, NewBTreeFunc(4, compareFuncTestKeys))
+}
+
+func TestFuncSkipList(t *testing.T) {
+	testFunc(t, NewSkipListFunc(compareFuncTestKeys))
+}
@@ -0,0 +1,157 @@
+package tree
+
+import (
+	"cmp"
+	"strconv"
+	"testing"
+)
+
+// testGeneric checks the basic operations of a tree with int keys and string
+// values. If reversed is true, the tree is ordered from largest to smallest.
+func testGeneric(t *testing.T, tree TreeOf[int, string], reversed bool) {
+	for _, v := range testRand.Perm(NUM_NODES) {
+		if _, ok := tree.Set(v, strconv.Itoa(v)); ok {
+			t.Fatalf("set failed: duplicate reported for %v\n", v)
+		}
+	}
+	if n := tree.Len(); n != NUM_NODES {
+		t.Errorf("len failed: got %v, expected %v\n", n, NUM_NODES)
+	}
+
+	for i := 0; i < NUM_NODES; i++ {
+		if v, ok := tree.Get(i); !ok || v != strconv.Itoa(i) {
+			t.Fatalf("get failed: got %q, %v for %v\n", v, ok, i)
+		}
+	}
+
+	expected := 0
+	step := 1
+	if reversed {
+		expected = NUM_NODES - 1
+		step = -1
+	}
+	for k, v := range tree.Ascend() {
+		if k != expected || v != strconv.Itoa(expected) {
+			t.Fatalf("ascend failed: got %v, %q, expected %v\n", k, v, expected)
+		}
+		expected += step
+	}
+
+	for _, v := range testRand.Perm(NUM_NODES) {
+		if ov, ok := tree.Del(v); !ok || ov != strconv.Itoa(v) {
+			t.Fatalf("delete failed: got %q, %v for %v\n", ov, ok, v)
+		}
+	}
+	if v, ok := tree.Get(0); ok || v != "" {
+		t.Errorf("get failed: got %q, %v from empty tree\n", v, ok)
+	}
+	if k, v, ok := tree.Min(); ok || k != 0 || v != "" {
+		t.Errorf("min failed: got %v, %q, %v from empty tree\n", k, v, ok)
+	}
+}
+
+func reverseInts(a, b int) int {
+	return cmp.Compare(b, a)
+}
+
+func TestGenericBST(t *testing.T) {
+	testGeneric(t, NewBSTOf[int, string](), false)
+	testGeneric(t, NewBSTOfFunc[int, string](reverseInts), true)
+}
+
+func TestGenericSplay(t *testing.T) {
+	testGeneric(t, NewSplayOf[int, string](), false)
+	testGeneric(t, NewSplayOfFunc[int, string](reverseInts), true)
+}
+
+func TestGenericOrderStatistic(t *testing.T) {
+	for _, tree := range []OrderStatisticTreeOf[int, string]{
+		NewOrderStatisticBSTOf[int, string](),
+		NewOrderStatisticSplayOf[int, string](),
+	} {
+		for _, v := range testRand.Perm(NUM_NODES) {
+			tree.Set(2*v, strconv.Itoa(v))
+		}
+		for i := 0; i < NUM_NODES; i++ {
+			if r := tree.Rank(2*i + 1); r != i+1 {
+				t.Fatalf("rank failed: got %v for %v, expected %v\n", r, 2*i+1, i+1)
+			}
+			if k, v, ok := tree.Select(i); !ok || k != 2*i || v != strconv.Itoa(i) {
+				t.Fatalf("select failed: got %v, %q, %v for %v\n", k, v, ok, i)
+			}
+		}
+	}
+
+	// The comparison function orders the ranks, too.
+	for _, tree := range []OrderStatisticTreeOf[int, string]{
+		NewOrderStatisticBSTOfFunc[int, string](reverseInts),
+		NewOrderStatisticSplayOfFunc[int, string](reverseInts),
+	} {
+		tree.Set(1, "1")
+		tree.Set(2, "2")
+		if k, _, ok := tree.Select(0); !ok || k != 2 {
+			t.Errorf("select failed: got %v, %v, expected 2\n", k, ok)
+		}
+	}
+}
+
+func TestGenericAVL(t *testing.T) {
+	testGeneric(t, NewAVLOf[int, string](), false)
+	testGeneric(t, NewAVLOfFunc[int, string](reverseInts), true)
+}
+
+func TestGenericRedBlack(t *testing.T) {
+	testGeneric(t, NewRedBlackOf[int, string](), false)
+	testGeneric(t, NewRedBlackOfFunc[int, string](reverseInts), true)
+}
+
+func TestGenericTreap(t *testing.T) {
+	testGeneric(t, NewTreapOf[int, string](), false)
+	testGeneric(t, NewTreapOfFunc[int, string](reverseInts), true)
+}
+
+func TestGenericBTree(t *testing.T) {
+	testGeneric(t, NewBTreeOf[int, string](4), false)
+	testGeneric(t, NewBTreeOfFunc[int, string](4, reverseInts), true)
+}
+
+func TestGenericSkipList(t *testing.T) {
+	testGeneric(t, NewSkipListOf[int, string](), false)
+	testGeneric(t, NewSkipListOfFunc[int, string](reverseInts), true)
+}
+
+// testGenericTrie checks the basic operations of a trie with string values.
+func testGenericTrie(t *testing.T, trie TrieOf[string]) {
+	for _, v := range testRand.Perm(NUM_NODES) {
+		trie.Set(uint64(v), strconv.Itoa(v))
+	}
+
+	expected := uint64(0)
+	for k, v := range trie.Ascend() {
+		if k != expected || v != strconv.Itoa(int(expected)) {
+			t.Fatalf("ascend failed: got %v, %q, expected %v\n", k, v, expected)
+		}
+		expected++
+	}
+
+	for _, v := range testRand.Perm(NUM_NODES) {
+		if ov, ok := trie.Del(uint64(v)); !ok || ov != strconv.Itoa(v) {
+			t.Fatalf("delete failed: got %q, %v for %v\n", ov, ok, v)
+		}
+	}
+	if v, ok := trie.Get(0); ok || v != "" {
+		t.Errorf("get failed: got %q, %v from empty trie\n", v, ok)
+	}
+}
+
+func TestGenericBinaryTrie(t *testing.T) {
+	testGenericTrie(t, NewBinaryTrieOf[string]())
+}
+
+func TestGenericCLZTrie(t *testing.T) {
+	testGenericTrie(t, NewCLZTrieOf[string]())
+}
+
+func TestGenericRadixTrie(t *testing.T) {
+	testGenericTrie(t, NewRadixTrieOf[string]())
+}
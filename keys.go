@@ -0,0 +1,100 @@
+package tree
+
+// Standard Key types.
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"strings"
+)
+
+// Int64Key is a Key ordered as a signed integer.
+type Int64Key int64
+
+func (n Int64Key) CompareTo(m Key) int {
+	if m, ok := m.(Int64Key); ok {
+		return cmp.Compare(n, m)
+	} else {
+		panic("invalid comparison")
+	}
+}
+
+// StringKey is a Key ordered lexicographically by bytes.
+type StringKey string
+
+func (s StringKey) CompareTo(t Key) int {
+	if t, ok := t.(StringKey); ok {
+		return strings.Compare(string(s), string(t))
+	} else {
+		panic("invalid comparison")
+	}
+}
+
+// BytesKey is a Key ordered lexicographically by bytes. A nil BytesKey is
+// equal to an empty one. The slice must not be modified while it is in a tree.
+type BytesKey []byte
+
+func (s BytesKey) CompareTo(t Key) int {
+	if t, ok := t.(BytesKey); ok {
+		return bytes.Compare(s, t)
+	} else {
+		panic("invalid comparison")
+	}
+}
+
+// Float64Key is a Key ordered numerically, except that the ordering is total:
+// NaNs are equal to each other and less than every other value, and -0 is less
+// than +0.
+type Float64Key float64
+
+func (x Float64Key) CompareTo(y Key) int {
+	if y, ok := y.(Float64Key); ok {
+		a, b := float64(x), float64(y)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		case a == b:
+			// Only the signs of zeros can differ.
+			if math.Signbit(a) == math.Signbit(b) {
+				return 0
+			} else if math.Signbit(a) {
+				return -1
+			} else {
+				return 1
+			}
+		}
+
+		// At least one of them is a NaN.
+		if math.IsNaN(a) && math.IsNaN(b) {
+			return 0
+		} else if math.IsNaN(a) {
+			return -1
+		} else {
+			return 1
+		}
+	} else {
+		panic("invalid comparison")
+	}
+}
+
+// TupleKey is a Key ordered lexicographically over its components: the first
+// differing component decides the order, and a tuple which is a prefix of
+// another is less than it. Corresponding components must be comparable with
+// each other.
+type TupleKey []Key
+
+func (s TupleKey) CompareTo(t Key) int {
+	if t, ok := t.(TupleKey); ok {
+		for i := 0; i < len(s) && i < len(t); i++ {
+			if c := s[i].CompareTo(t[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(s), len(t))
+	} else {
+		panic("invalid comparison")
+	}
+}
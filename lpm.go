@@ -0,0 +1,265 @@
+package tree
+
+// Longest-prefix-match tables built on the bitwise trie.
+
+import (
+	"encoding/binary"
+	"net/netip"
+)
+
+// Longest-prefix-match table with interface{} values.
+type LPMTable = LPMTableOf[interface{}]
+
+// Longest-prefix-match table with values of type V. Entries are keyed by a
+// prefix and its length in bits, as in TrieOf.WalkPrefix: the entry (5, 8)
+// matches the keys from 0x05000000... through 0x05ffffff.... Insert, Remove,
+// and Get panic if the length is greater than 64 or the prefix does not fit in
+// it.
+type LPMTableOf[V any] interface {
+	// Insert adds an entry with the given prefix and value. If there was
+	// already an entry with the prefix, it returns the old value and true;
+	// otherwise, it returns false.
+	Insert(prefix uint64, bits uint, value V) (V, bool)
+
+	// Remove removes the entry with the given prefix. If there was one, it
+	// returns its value and true; otherwise, it returns false.
+	Remove(prefix uint64, bits uint) (V, bool)
+
+	// Get returns the value of the entry with exactly the given prefix. If
+	// there is no such entry, it returns false.
+	Get(prefix uint64, bits uint) (V, bool)
+
+	// Lookup finds the most specific entry which matches the given key and
+	// returns its prefix, length, and value. If no entry matches, it returns
+	// false.
+	Lookup(key uint64) (uint64, uint, V, bool)
+
+	// Len returns the number of entries in the table.
+	Len() int
+}
+
+// IP routing table with interface{} values.
+type IPTable = IPTableOf[interface{}]
+
+// IP routing table with values of type V which maps IPv4 and IPv6 prefixes to
+// values. IPv4 and IPv6 entries are kept separately, so an IPv4-mapped IPv6
+// address only matches IPv6 entries; use netip.Addr.Unmap to look it up as an
+// IPv4 address instead. Insert, Remove, and Get mask the prefix and panic if
+// it is invalid.
+type IPTableOf[V any] interface {
+	// Insert adds an entry with the given prefix and value. If there was
+	// already an entry with the prefix, it returns the old value and true;
+	// otherwise, it returns false.
+	Insert(netip.Prefix, V) (V, bool)
+
+	// Remove removes the entry with the given prefix. If there was one, it
+	// returns its value and true; otherwise, it returns false.
+	Remove(netip.Prefix) (V, bool)
+
+	// Get returns the value of the entry with exactly the given prefix. If
+	// there is no such entry, it returns false.
+	Get(netip.Prefix) (V, bool)
+
+	// Lookup finds the most specific entry which contains the given address
+	// and returns its prefix and value. If no entry matches, it returns false.
+	Lookup(netip.Addr) (netip.Prefix, V, bool)
+
+	// Len returns the number of entries in the table.
+	Len() int
+}
+
+// Longest-prefix-match trie over keys of up to 128 bits. A node at depth n
+// represents the prefix of the first n bits on the path to it, and its value is
+// nil if there is no entry for that prefix. It shares its nodes and the paths
+// through them with the binary trie with 128-bit keys.
+type lpmTrie[V any] struct {
+	root trieNode[*V]
+
+	// Number of entries in the trie.
+	size int
+}
+
+// Longest-prefix-match table with uint64 keys.
+type lpmTable[V any] struct {
+	lpmTrie[V]
+}
+
+// IP routing table.
+type ipTable[V any] struct {
+	v4, v6 lpmTrie[V]
+}
+
+// NewLPMTable creates an empty longest-prefix-match table. It is a binary trie
+// with an entry on every node whose prefix was inserted, so all operations are
+// O(m), where m is the length of the prefix or key.
+func NewLPMTable() LPMTable {
+	return NewLPMTableOf[interface{}]()
+}
+
+// NewLPMTableOf creates an empty longest-prefix-match table with values of type
+// V. See NewLPMTable.
+func NewLPMTableOf[V any]() LPMTableOf[V] {
+	return new(lpmTable[V])
+}
+
+// NewIPTable creates an empty IP routing table. IPv4 addresses are looked up
+// in a trie with 32-bit keys and IPv6 addresses in one with 128-bit keys; see
+// NewLPMTable.
+func NewIPTable() IPTable {
+	return NewIPTableOf[interface{}]()
+}
+
+// NewIPTableOf creates an empty IP routing table with values of type V. See
+// NewIPTable.
+func NewIPTableOf[V any]() IPTableOf[V] {
+	return new(ipTable[V])
+}
+
+func (lt *lpmTable[V]) Insert(prefix uint64, bits uint, value V) (V, bool) {
+	return lt.insert(lpmKey(prefix, bits), bits, value)
+}
+
+func (lt *lpmTable[V]) Remove(prefix uint64, bits uint) (V, bool) {
+	return lt.remove(lpmKey(prefix, bits), bits)
+}
+
+func (lt *lpmTable[V]) Get(prefix uint64, bits uint) (V, bool) {
+	return lt.get(lpmKey(prefix, bits), bits)
+}
+
+func (lt *lpmTable[V]) Lookup(key uint64) (uint64, uint, V, bool) {
+	bits, value := lt.lookup(Uint128{key, 0}, 64)
+	if value == nil {
+		var v V
+		return 0, 0, v, false
+	}
+	return key >> (64 - bits), bits, *value, true
+}
+
+func (lt *lpmTable[V]) Len() int {
+	return lt.size
+}
+
+// lpmKey returns the smallest key with the given prefix in the high half of a
+// 128-bit key, panicking if the prefix is invalid.
+func lpmKey(prefix uint64, bits uint) Uint128 {
+	key, _, ok := triePrefixRange(prefix, bits)
+	if !ok {
+		panic("invalid prefix")
+	}
+	return Uint128{key, 0}
+}
+
+func (it *ipTable[V]) Insert(prefix netip.Prefix, value V) (V, bool) {
+	lt, key, bits := it.prefix(prefix)
+	return lt.insert(key, bits, value)
+}
+
+func (it *ipTable[V]) Remove(prefix netip.Prefix) (V, bool) {
+	lt, key, bits := it.prefix(prefix)
+	return lt.remove(key, bits)
+}
+
+func (it *ipTable[V]) Get(prefix netip.Prefix) (V, bool) {
+	lt, key, bits := it.prefix(prefix)
+	return lt.get(key, bits)
+}
+
+func (it *ipTable[V]) Lookup(addr netip.Addr) (netip.Prefix, V, bool) {
+	if addr.IsValid() {
+		lt, key := it.addr(addr)
+		bits, value := lt.lookup(key, uint(addr.BitLen()))
+		if value != nil {
+			prefix, _ := addr.Prefix(int(bits))
+			return prefix, *value, true
+		}
+	}
+
+	var value V
+	return netip.Prefix{}, value, false
+}
+
+func (it *ipTable[V]) Len() int {
+	return it.v4.size + it.v6.size
+}
+
+// prefix returns the trie and key for the given prefix, panicking if it is
+// invalid.
+func (it *ipTable[V]) prefix(prefix netip.Prefix) (*lpmTrie[V], Uint128, uint) {
+	if !prefix.IsValid() {
+		panic("invalid prefix")
+	}
+	prefix = prefix.Masked()
+	lt, key := it.addr(prefix.Addr())
+	return lt, key, uint(prefix.Bits())
+}
+
+// addr returns the trie and key for the given valid address.
+func (it *ipTable[V]) addr(addr netip.Addr) (*lpmTrie[V], Uint128) {
+	if addr.Is4() {
+		a := addr.As4()
+		return &it.v4, Uint128{uint64(binary.BigEndian.Uint32(a[:])) << 32, 0}
+	} else {
+		return &it.v6, Uint128From16(addr.As16())
+	}
+}
+
+func (lt *lpmTrie[V]) insert(key Uint128, bits uint, value V) (V, bool) {
+	node, _ := lt.root.insert128(key, bits)
+	if node.value != nil {
+		origValue := *node.value
+		node.value = &value
+		return origValue, true
+	}
+	node.value = &value
+	lt.size++
+	var origValue V
+	return origValue, false
+}
+
+func (lt *lpmTrie[V]) remove(key Uint128, bits uint) (V, bool) {
+	var path [129]*trieNode[*V]
+	node := lt.root.find128(key, bits, &path)
+	if node == nil || node.value == nil {
+		var value V
+		return value, false
+	}
+	origValue := *node.value
+	node.value = nil
+	lt.size--
+
+	// Nodes for shorter prefixes with entries must be kept.
+	trieNode128Prune(&path, key, bits, func(node *trieNode[*V]) bool {
+		return node.value != nil
+	})
+	return origValue, true
+}
+
+func (lt *lpmTrie[V]) get(key Uint128, bits uint) (V, bool) {
+	node := lt.root.find128(key, bits, nil)
+	if node == nil || node.value == nil {
+		var value V
+		return value, false
+	}
+	return *node.value, true
+}
+
+// lookup finds the longest prefix of the first maxBits bits of the given key
+// which has an entry and returns its length and value. The value is nil if no
+// prefix has an entry.
+func (lt *lpmTrie[V]) lookup(key Uint128, maxBits uint) (uint, *V) {
+	var bits uint
+	var value *V
+
+	node := &lt.root
+	for i := uint(0); node != nil; i++ {
+		if node.value != nil {
+			bits, value = i, node.value
+		}
+		if i == maxBits {
+			break
+		}
+		node = node.children[key.bit(i)]
+	}
+	return bits, value
+}
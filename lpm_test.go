@@ -0,0 +1,165 @@
+package tree
+
+import (
+	"net/netip"
+	"testing"
+)
+
+type lpmTestEntry struct {
+	prefix uint64
+	bits   uint
+}
+
+// lpmTestLookup finds the most specific entry matching a key by scanning all of
+// the entries.
+func lpmTestLookup(entries map[lpmTestEntry]int, key uint64) (lpmTestEntry, bool) {
+	var best lpmTestEntry
+	found := false
+	for e := range entries {
+		if key>>(64-e.bits) == e.prefix && (!found || e.bits > best.bits) {
+			best, found = e, true
+		}
+	}
+	return best, found
+}
+
+func TestLPMTable(t *testing.T) {
+	table := NewLPMTableOf[int]()
+	entries := map[lpmTestEntry]int{}
+
+	// Use short prefixes of a few random keys so that entries nest.
+	var keys []uint64
+	for i := 0; i < 8; i++ {
+		keys = append(keys, testRand.Uint64())
+	}
+	for i := 0; i < NUM_NODES/10; i++ {
+		bits := uint(testRand.Intn(65))
+		e := lpmTestEntry{keys[testRand.Intn(len(keys))] >> (64 - bits), bits}
+		_, existed := entries[e]
+		if _, ok := table.Insert(e.prefix, e.bits, i); ok != existed {
+			t.Fatalf("insert failed: got %v for %v, expected %v\n", ok, e, existed)
+		}
+		entries[e] = i
+	}
+	if n := table.Len(); n != len(entries) {
+		t.Errorf("len failed: got %v, expected %v\n", n, len(entries))
+	}
+
+	check := func() {
+		for i := 0; i < NUM_NODES/10; i++ {
+			// Perturb the low bits of a key to match a varying number of
+			// entries.
+			key := keys[testRand.Intn(len(keys))] ^ (1<<testRand.Intn(64) - 1)
+			e, found := lpmTestLookup(entries, key)
+			prefix, bits, v, ok := table.Lookup(key)
+			if ok != found || (ok && (prefix != e.prefix || bits != e.bits || v != entries[e])) {
+				t.Fatalf("lookup failed: got %v, %v, %v, %v for %x, expected %v, %v\n",
+					prefix, bits, v, ok, key, e, found)
+			}
+		}
+	}
+	check()
+
+	for e, w := range entries {
+		if v, ok := table.Get(e.prefix, e.bits); !ok || v != w {
+			t.Fatalf("get failed: got %v, %v for %v, expected %v\n", v, ok, e, w)
+		}
+		if testRand.Intn(2) == 0 {
+			if v, ok := table.Remove(e.prefix, e.bits); !ok || v != w {
+				t.Fatalf("remove failed: got %v, %v for %v, expected %v\n", v, ok, e, w)
+			}
+			if _, ok := table.Remove(e.prefix, e.bits); ok {
+				t.Fatalf("remove failed: removed %v twice\n", e)
+			}
+			delete(entries, e)
+		}
+	}
+	check()
+
+	for e := range entries {
+		table.Remove(e.prefix, e.bits)
+	}
+	root := &table.(*lpmTable[int]).root
+	if table.Len() != 0 || root.children[0] != nil || root.children[1] != nil {
+		t.Errorf("remove failed: nodes left in empty table\n")
+	}
+}
+
+func TestLPMTableInvalid(t *testing.T) {
+	table := NewLPMTable()
+	for _, f := range []func(){
+		func() { table.Insert(0, 65, nil) },
+		func() { table.Insert(2, 1, nil) },
+		func() { table.Get(1, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("invalid prefix did not panic\n")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestIPTable(t *testing.T) {
+	table := NewIPTableOf[string]()
+	for _, s := range []string{
+		"0.0.0.0/0",
+		"10.0.0.0/8",
+		"10.1.0.0/16",
+		"10.1.2.3/32",
+		"192.168.0.0/16",
+		"::/0",
+		"2001:db8::/32",
+		"2001:db8:1::/48",
+		"::ffff:0:0/96",
+	} {
+		if _, ok := table.Insert(netip.MustParsePrefix(s), s); ok {
+			t.Errorf("insert failed: duplicate reported for %v\n", s)
+		}
+	}
+	if n := table.Len(); n != 9 {
+		t.Errorf("len failed: got %v, expected 9\n", n)
+	}
+
+	// Insert masks the prefix.
+	if v, ok := table.Insert(netip.MustParsePrefix("10.1.255.255/16"), "10.1.0.0/16"); !ok || v != "10.1.0.0/16" {
+		t.Errorf("insert failed: got %v, %v for unmasked prefix\n", v, ok)
+	}
+
+	for _, test := range []struct{ addr, expected string }{
+		{"10.1.2.3", "10.1.2.3/32"},
+		{"10.1.2.4", "10.1.0.0/16"},
+		{"10.2.0.1", "10.0.0.0/8"},
+		{"192.168.255.1", "192.168.0.0/16"},
+		{"8.8.8.8", "0.0.0.0/0"},
+		{"2001:db8:1:2::1", "2001:db8:1::/48"},
+		{"2001:db8:2::1", "2001:db8::/32"},
+		{"2001:db9::1", "::/0"},
+		{"::ffff:10.1.2.3", "::ffff:0:0/96"},
+	} {
+		prefix, v, ok := table.Lookup(netip.MustParseAddr(test.addr))
+		if !ok || v != test.expected || prefix != netip.MustParsePrefix(test.expected) {
+			t.Errorf("lookup failed: got %v, %v, %v for %v, expected %v\n",
+				prefix, v, ok, test.addr, test.expected)
+		}
+	}
+
+	if _, ok := table.Remove(netip.MustParsePrefix("0.0.0.0/0")); !ok {
+		t.Errorf("remove failed: default route missing\n")
+	}
+	if _, _, ok := table.Lookup(netip.MustParseAddr("8.8.8.8")); ok {
+		t.Errorf("lookup failed: found removed default route\n")
+	}
+	if _, _, ok := table.Lookup(netip.Addr{}); ok {
+		t.Errorf("lookup failed: found invalid address\n")
+	}
+	if v, ok := table.Get(netip.MustParsePrefix("2001:db8::/32")); !ok || v != "2001:db8::/32" {
+		t.Errorf("get failed: got %v, %v\n", v, ok)
+	}
+	if _, ok := table.Get(netip.MustParsePrefix("2001:db8::/33")); ok {
+		t.Errorf("get failed: found missing prefix\n")
+	}
+}
@@ -0,0 +1,366 @@
+package tree
+
+// Persistent AVL tree implementation.
+
+import (
+	"cmp"
+	"iter"
+)
+
+// PersistentTree ordered map with Key keys and interface{} values which is
+// never modified in place.
+type PersistentTree = PersistentTreeOf[Key, interface{}]
+
+// PersistentTreeOf ordered map with keys of type K and values of type V which
+// is never modified in place. Set and Del return a new version of the tree and
+// leave the receiver unchanged, so every version remains valid and can be used
+// concurrently with the others. The methods which PersistentTreeOf shares with
+// TreeOf behave the same way, except that iteration remains valid while other
+// versions are created.
+type PersistentTreeOf[K, V any] interface {
+	Get(K) (V, bool)
+
+	// Set returns a version of the tree with the given key set to the given
+	// value.
+	Set(K, V) PersistentTreeOf[K, V]
+
+	// Del returns a version of the tree without the given key. If the key was
+	// not in the tree, it returns the receiver.
+	Del(K) PersistentTreeOf[K, V]
+
+	Ascend() iter.Seq2[K, V]
+	Descend() iter.Seq2[K, V]
+	Range(lo, hi K) iter.Seq2[K, V]
+	Min() (K, V, bool)
+	Max() (K, V, bool)
+	Floor(K) (K, V, bool)
+	Ceiling(K) (K, V, bool)
+	Predecessor(K) (K, V, bool)
+	Successor(K) (K, V, bool)
+	Len() int
+}
+
+// Persistent AVL tree.
+type persistentTree[K, V any] struct {
+	// Root of the tree.
+	root *persistentNode[K, V]
+
+	// Number of keys in the tree.
+	size int
+
+	// Comparison function for keys.
+	compare func(K, K) int
+}
+
+// Node in a persistent AVL tree. Nodes are never modified once they are
+// reachable from a tree, so they may be shared between versions. As in
+// bstNode, keys greater than the node's key are in its left subtree and keys
+// less than the node's key are in its right subtree.
+type persistentNode[K, V any] struct {
+	key         K
+	value       V
+	left, right *persistentNode[K, V]
+
+	// Height of the subtree rooted at this node; a leaf has height 0.
+	height int
+}
+
+// NewPersistent creates an empty persistent tree. It is an AVL tree which
+// copies the path from the root to every node it changes, so Get, Set, and Del
+// are O(log n) and each version shares all but O(log n) nodes with the version
+// it was created from.
+func NewPersistent() PersistentTree {
+	return NewPersistentOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewPersistentOf creates an empty persistent tree ordered by the natural order
+// of its keys. See NewPersistent.
+func NewPersistentOf[K cmp.Ordered, V any]() PersistentTreeOf[K, V] {
+	return NewPersistentOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewPersistentOfFunc creates an empty persistent tree ordered by the given
+// comparison function. See NewPersistent and NewBSTOfFunc.
+func NewPersistentOfFunc[K, V any](compare func(K, K) int) PersistentTreeOf[K, V] {
+	return &persistentTree[K, V]{compare: compare}
+}
+
+// NewPersistentFunc creates an empty persistent tree ordered by the given
+// comparison function. See NewPersistent and NewBSTFunc.
+func NewPersistentFunc(compare func(a, b interface{}) int) PersistentTreeOf[interface{}, interface{}] {
+	return NewPersistentOfFunc[interface{}, interface{}](compare)
+}
+
+func (t *persistentTree[K, V]) Get(key K) (V, bool) {
+	node := t.root
+	for node != nil {
+		c := t.compare(node.key, key)
+		if c < 0 {
+			node = node.left
+		} else if c > 0 {
+			node = node.right
+		} else {
+			return node.value, true
+		}
+	}
+
+	var value V
+	return value, false
+}
+
+func (t *persistentTree[K, V]) Set(key K, value V) PersistentTreeOf[K, V] {
+	root, added := t.set(t.root, key, value)
+	size := t.size
+	if added {
+		size++
+	}
+	return &persistentTree[K, V]{root, size, t.compare}
+}
+
+// set returns a copy of the subtree rooted at the given node with the given key
+// set to the given value and whether the key was added.
+func (t *persistentTree[K, V]) set(node *persistentNode[K, V], key K, value V) (*persistentNode[K, V], bool) {
+	if node == nil {
+		return &persistentNode[K, V]{key: key, value: value}, true
+	}
+
+	c := t.compare(node.key, key)
+	if c < 0 {
+		left, added := t.set(node.left, key, value)
+		return persistentBalance(node.key, node.value, left, node.right), added
+	} else if c > 0 {
+		right, added := t.set(node.right, key, value)
+		return persistentBalance(node.key, node.value, node.left, right), added
+	} else {
+		return newPersistentNode(key, value, node.left, node.right), false
+	}
+}
+
+func (t *persistentTree[K, V]) Del(key K) PersistentTreeOf[K, V] {
+	root, ok := t.del(t.root, key)
+	if !ok {
+		return t
+	}
+	return &persistentTree[K, V]{root, t.size - 1, t.compare}
+}
+
+// del returns a copy of the subtree rooted at the given node without the given
+// key. If the key is not in the subtree, it returns false and the subtree is
+// not copied.
+func (t *persistentTree[K, V]) del(node *persistentNode[K, V], key K) (*persistentNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	c := t.compare(node.key, key)
+	if c < 0 {
+		left, ok := t.del(node.left, key)
+		if !ok {
+			return node, false
+		}
+		return persistentBalance(node.key, node.value, left, node.right), true
+	} else if c > 0 {
+		right, ok := t.del(node.right, key)
+		if !ok {
+			return node, false
+		}
+		return persistentBalance(node.key, node.value, node.left, right), true
+	}
+
+	if node.left == nil {
+		return node.right, true
+	} else if node.right == nil {
+		return node.left, true
+	}
+
+	// The node has two children, so replace it with its predecessor, which is
+	// the largest key in its right subtree.
+	right, pred := persistentDelMax(node.right)
+	return persistentBalance(pred.key, pred.value, node.left, right), true
+}
+
+// persistentDelMax returns a copy of the non-empty subtree rooted at the given
+// node without its largest key and the node which held that key.
+func persistentDelMax[K, V any](node *persistentNode[K, V]) (*persistentNode[K, V], *persistentNode[K, V]) {
+	if node.left == nil {
+		return node.right, node
+	}
+	left, max := persistentDelMax(node.left)
+	return persistentBalance(node.key, node.value, left, node.right), max
+}
+
+func (t *persistentTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+func (t *persistentTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.descend(yield)
+	}
+}
+
+func (t *persistentTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+func (t *persistentTree[K, V]) Min() (K, V, bool) {
+	node := t.root
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return node.entry()
+}
+
+func (t *persistentTree[K, V]) Max() (K, V, bool) {
+	node := t.root
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return node.entry()
+}
+
+func (t *persistentTree[K, V]) Floor(key K) (K, V, bool) {
+	return t.nearest(key, false, true).entry()
+}
+
+func (t *persistentTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return t.nearest(key, true, true).entry()
+}
+
+func (t *persistentTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return t.nearest(key, false, false).entry()
+}
+
+func (t *persistentTree[K, V]) Successor(key K) (K, V, bool) {
+	return t.nearest(key, true, false).entry()
+}
+
+func (t *persistentTree[K, V]) Len() int {
+	return t.size
+}
+
+// nearest finds the node with the smallest key greater than the given key if
+// above is true or the largest key less than the given key otherwise. If
+// inclusive is true, the node with the given key itself is returned if it
+// exists.
+func (t *persistentTree[K, V]) nearest(key K, above, inclusive bool) *persistentNode[K, V] {
+	var best *persistentNode[K, V]
+	node := t.root
+	for node != nil {
+		c := t.compare(node.key, key)
+		if c == 0 && inclusive {
+			return node
+		}
+		if c != 0 && (c > 0) == above {
+			best = node
+		}
+
+		if c < 0 || (c == 0 && above) {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return best
+}
+
+// ascend yields the keys and values in the subtree rooted at the given node
+// which are greater than or equal to lo and less than hi in ascending order.
+// A nil bound is unbounded. It returns false if yield returned false.
+func (t *persistentTree[K, V]) ascend(node *persistentNode[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	aboveLo := lo == nil || t.compare(node.key, *lo) >= 0
+	belowHi := hi == nil || t.compare(node.key, *hi) < 0
+	if aboveLo && !t.ascend(node.right, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(node.key, node.value) {
+		return false
+	}
+	if belowHi && !t.ascend(node.left, lo, hi, yield) {
+		return false
+	}
+	return true
+}
+
+// descend yields the keys and values in the subtree rooted at the node in
+// descending order. It returns false if yield returned false.
+func (node *persistentNode[K, V]) descend(yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return node.left.descend(yield) && yield(node.key, node.value) && node.right.descend(yield)
+}
+
+// entry returns the key and value of the node, or false if the node is nil.
+func (node *persistentNode[K, V]) entry() (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	} else {
+		return node.key, node.value, true
+	}
+}
+
+// getHeight returns the height of the subtree rooted at the node, which is -1
+// for an empty subtree.
+func (node *persistentNode[K, V]) getHeight() int {
+	if node == nil {
+		return -1
+	} else {
+		return node.height
+	}
+}
+
+// newPersistentNode creates a node with the given subtrees, which must be
+// balanced with respect to each other.
+func newPersistentNode[K, V any](key K, value V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	return &persistentNode[K, V]{
+		key:    key,
+		value:  value,
+		left:   left,
+		right:  right,
+		height: max(left.getHeight(), right.getHeight()) + 1,
+	}
+}
+
+// persistentBalance creates a node with the given subtrees, whose heights may
+// differ by at most two, and rotates it as necessary to restore the AVL
+// property. It returns the root of the resulting subtree.
+func persistentBalance[K, V any](key K, value V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	lh, rh := left.getHeight(), right.getHeight()
+
+	if lh > rh+1 {
+		if left.left.getHeight() < left.right.getHeight() {
+			// Left-right case.
+			lr := left.right
+			return newPersistentNode(lr.key, lr.value,
+				newPersistentNode(left.key, left.value, left.left, lr.left),
+				newPersistentNode(key, value, lr.right, right))
+		}
+		// Left-left case.
+		return newPersistentNode(left.key, left.value, left.left,
+			newPersistentNode(key, value, left.right, right))
+	} else if rh > lh+1 {
+		if right.right.getHeight() < right.left.getHeight() {
+			// Right-left case.
+			rl := right.left
+			return newPersistentNode(rl.key, rl.value,
+				newPersistentNode(key, value, left, rl.left),
+				newPersistentNode(right.key, right.value, rl.right, right.right))
+		}
+		// Right-right case.
+		return newPersistentNode(right.key, right.value,
+			newPersistentNode(key, value, left, right.left), right.right)
+	}
+
+	return newPersistentNode(key, value, left, right)
+}
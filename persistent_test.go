@@ -0,0 +1,150 @@
+package tree
+
+import (
+	"testing"
+)
+
+// checkPersistent checks the ordering and AVL property of a subtree and returns
+// its height.
+func checkPersistent(t *testing.T, node *persistentNode[Key, interface{}]) int {
+	if node == nil {
+		return -1
+	}
+
+	if node.left != nil && node.left.key.CompareTo(node.key) <= 0 {
+		t.Fatalf("misordered node %v: left child %v\n", node.key, node.left.key)
+	}
+	if node.right != nil && node.right.key.CompareTo(node.key) >= 0 {
+		t.Fatalf("misordered node %v: right child %v\n", node.key, node.right.key)
+	}
+
+	left := checkPersistent(t, node.left)
+	right := checkPersistent(t, node.right)
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("unbalanced node %v: left height %v, right height %v\n",
+			node.key, left, right)
+	}
+	if height := 1 + max(left, right); node.height != height {
+		t.Fatalf("incorrect height for %v: got %v, expected %v\n",
+			node.key, node.height, height)
+	}
+	return node.height
+}
+
+// checkVersion checks that a version of a persistent tree contains exactly the
+// given keys and values in ascending order.
+func checkVersion(t *testing.T, tree PersistentTree, m map[int]int) {
+	checkPersistent(t, tree.(*persistentTree[Key, interface{}]).root)
+
+	if n := tree.Len(); n != len(m) {
+		t.Fatalf("len failed: got %v, expected %v\n", n, len(m))
+	}
+
+	prev, n := -1, 0
+	for k, v := range tree.Ascend() {
+		i := int(k.(Uint64Key))
+		if i <= prev {
+			t.Fatalf("ascend failed: got %v after %v\n", i, prev)
+		}
+		if w, ok := m[i]; !ok || v != w {
+			t.Fatalf("ascend failed: got %v for %v, expected %v, %v\n", v, i, w, ok)
+		}
+		prev = i
+		n++
+	}
+	if n != len(m) {
+		t.Fatalf("ascend failed: visited %v keys, expected %v\n", n, len(m))
+	}
+}
+
+func TestPersistentVersions(t *testing.T) {
+	const numVersions = 100
+
+	tree := NewPersistent()
+	m := map[int]int{}
+	var versions []PersistentTree
+	var maps []map[int]int
+
+	for i := 0; i < numVersions; i++ {
+		versions = append(versions, tree)
+		snapshot := make(map[int]int, len(m))
+		for k, v := range m {
+			snapshot[k] = v
+		}
+		maps = append(maps, snapshot)
+
+		for j := 0; j < NUM_NODES/numVersions; j++ {
+			k := testRand.Intn(NUM_NODES / 10)
+			if testRand.Intn(3) == 0 {
+				tree = tree.Del(Uint64Key(k))
+				delete(m, k)
+			} else {
+				v := testRand.Int()
+				tree = tree.Set(Uint64Key(k), v)
+				m[k] = v
+			}
+		}
+	}
+	versions = append(versions, tree)
+	maps = append(maps, m)
+
+	for i := range versions {
+		checkVersion(t, versions[i], maps[i])
+	}
+}
+
+func TestPersistentDelMissing(t *testing.T) {
+	tree := NewPersistent()
+	for _, v := range testRand.Perm(NUM_NODES) {
+		tree = tree.Set(Uint64Key(2*v), v)
+	}
+
+	for i := 0; i < NUM_NODES; i++ {
+		if tree.Del(Uint64Key(2*i+1)) != tree {
+			t.Fatalf("delete failed: copied tree for missing key %v\n", 2*i+1)
+		}
+	}
+}
+
+func TestPersistentNearest(t *testing.T) {
+	tree := NewPersistent()
+	for _, v := range testRand.Perm(NUM_NODES) {
+		tree = tree.Set(Uint64Key(2*v+1), v)
+	}
+
+	for i := 0; i <= 2*NUM_NODES+1; i++ {
+		k, _, ok := tree.Floor(Uint64Key(i))
+		if (i == 0) == ok || (ok && k != Uint64Key(min((i-1)|1, 2*NUM_NODES-1))) {
+			t.Errorf("floor failed: got %v, %v for %v\n", k, ok, i)
+		}
+		k, _, ok = tree.Successor(Uint64Key(i))
+		if (i >= 2*NUM_NODES-1) == ok || (ok && k != Uint64Key((i+1)|1)) {
+			t.Errorf("successor failed: got %v, %v for %v\n", k, ok, i)
+		}
+	}
+
+	n := 0
+	for k := range tree.Range(Uint64Key(NUM_NODES/2), Uint64Key(NUM_NODES)) {
+		if i := int(k.(Uint64Key)); i < NUM_NODES/2 || i >= NUM_NODES {
+			t.Errorf("range failed: got %v\n", i)
+		}
+		n++
+	}
+	if n != NUM_NODES/4 {
+		t.Errorf("range failed: visited %v keys, expected %v\n", n, NUM_NODES/4)
+	}
+
+	prev := Key(nil)
+	for k := range tree.Descend() {
+		if prev != nil && k.CompareTo(prev) >= 0 {
+			t.Fatalf("descend failed: got %v after %v\n", k, prev)
+		}
+		prev = k
+	}
+	if k, _, _ := tree.Min(); k != Uint64Key(1) {
+		t.Errorf("min failed: got %v\n", k)
+	}
+	if k, _, _ := tree.Max(); k != Uint64Key(2*NUM_NODES-1) {
+		t.Errorf("max failed: got %v\n", k)
+	}
+}
@@ -2,6 +2,13 @@ package tree
 
 // Path-compressed radix trie implementation.
 
+import (
+	"iter"
+	"math"
+)
+
+// Default radix trie parameters. RADIX_WIDTH is the number of bits indexed at
+// each level, and RADIX_LIMIT is the highest level.
 const (
 	RADIX_WIDTH = 4
 	RADIX_COUNT = 1 << RADIX_WIDTH
@@ -9,93 +16,196 @@ const (
 	RADIX_LIMIT = (64+(RADIX_WIDTH+1))/RADIX_WIDTH - 1
 )
 
+// Maximum number of levels in a radix trie of any width, which is
+// radixLimit(1) + 1.
+const radixMaxLevels = 66
+
+// Path-compressed radix trie with interface{} values which supports
+// snapshots.
+type RadixTrie = RadixTrieOf[interface{}]
+
+// Path-compressed radix trie with values of type V which supports snapshots.
+type RadixTrieOf[V any] interface {
+	TrieOf[V]
+
+	// Snapshot returns a read-only view of the current contents of the trie in
+	// O(1). Later modifications of the trie copy the nodes they would change
+	// instead of modifying nodes shared with a snapshot, so a snapshot may be
+	// read concurrently with modifications of the trie, but Snapshot itself
+	// must not be called concurrently with them. Set and Del panic on the
+	// returned trie.
+	Snapshot() TrieOf[V]
+}
+
 // Radix trie.
-type radixTrie struct {
+type radixTrie[V any, C radixChildren] struct {
 	root radixTrieNode
+
+	// Number of keys in the trie.
+	size int
+
+	// Current generation of the trie. Nodes from an older generation may be
+	// shared with a snapshot and must be copied before they are modified.
+	gen uint64
+
+	// Number of bits indexed at each level and the highest level.
+	width, limit uint
+}
+
+// Read-only snapshot of a radix trie.
+type radixSnapshot[V any, C radixChildren] struct {
+	*radixTrie[V, C]
 }
 
 type radixTrieNode interface{}
 
+// Array of the children of a radix trie node, with one slot for each value of
+// the bits indexed at a level. Each width has its own instantiation of the trie
+// so that the children are stored in the node itself.
+type radixChildren interface {
+	[2]radixTrieNode | [4]radixTrieNode | [8]radixTrieNode | [16]radixTrieNode |
+		[32]radixTrieNode | [64]radixTrieNode | [128]radixTrieNode | [256]radixTrieNode
+}
+
 // Node in a bitwise trie.
-type radixNode struct {
+type radixNode[V any, C radixChildren] struct {
 	key      uint64
 	level    uint
 	count    uint
-	children [RADIX_COUNT]radixTrieNode
+	gen      uint64
+	children C
 }
 
 // Leaf containing a value in a bitwise trie.
-type radixLeaf struct {
+type radixLeaf[V any] struct {
 	key   uint64
-	value interface{}
+	value V
+	gen   uint64
 }
 
 // NewRadixTrie creates an empty path-compressed radix trie.
-func NewRadixTrie() Trie {
-	return new(radixTrie)
+func NewRadixTrie() RadixTrie {
+	return NewRadixTrieOf[interface{}]()
+}
+
+// NewRadixTrieOf creates an empty path-compressed radix trie with values of
+// type V. See NewRadixTrie.
+func NewRadixTrieOf[V any]() RadixTrieOf[V] {
+	return newRadixTrie[V, [RADIX_COUNT]radixTrieNode](RADIX_WIDTH)
+}
+
+// NewRadixTrieWidth creates an empty path-compressed radix trie which indexes
+// the given number of bits, between 1 and 8, at each level instead of
+// RADIX_WIDTH. Wider nodes make the trie shallower, so lookups visit fewer
+// nodes, but each node has 2^bits slots, so sparse key sets use more memory.
+func NewRadixTrieWidth(bits uint) RadixTrie {
+	return NewRadixTrieWidthOf[interface{}](bits)
+}
+
+// NewRadixTrieWidthOf creates an empty path-compressed radix trie with values
+// of type V and the given width. See NewRadixTrieWidth.
+func NewRadixTrieWidthOf[V any](bits uint) RadixTrieOf[V] {
+	switch bits {
+	case 1:
+		return newRadixTrie[V, [2]radixTrieNode](bits)
+	case 2:
+		return newRadixTrie[V, [4]radixTrieNode](bits)
+	case 3:
+		return newRadixTrie[V, [8]radixTrieNode](bits)
+	case 4:
+		return newRadixTrie[V, [16]radixTrieNode](bits)
+	case 5:
+		return newRadixTrie[V, [32]radixTrieNode](bits)
+	case 6:
+		return newRadixTrie[V, [64]radixTrieNode](bits)
+	case 7:
+		return newRadixTrie[V, [128]radixTrieNode](bits)
+	case 8:
+		return newRadixTrie[V, [256]radixTrieNode](bits)
+	default:
+		panic("invalid radix trie width")
+	}
 }
 
-func (rtrie *radixTrie) Get(key uint64) (interface{}, bool) {
+func newRadixTrie[V any, C radixChildren](width uint) *radixTrie[V, C] {
+	return &radixTrie[V, C]{width: width, limit: radixLimit(width)}
+}
+
+func (rtrie *radixTrie[V, C]) Get(key uint64) (V, bool) {
 	node := rtrie.root
 
 	for node != nil {
-		if leaf, ok := node.(*radixLeaf); ok {
+		if leaf, ok := node.(*radixLeaf[V]); ok {
 			if leaf.key == key {
 				return leaf.value, true
 			} else {
 				break
 			}
 		} else {
-			rnode := node.(*radixNode)
-			if rnode.notDescendant(key) {
+			rnode := node.(*radixNode[V, C])
+			if rnode.notDescendant(key, rtrie.width, rtrie.limit) {
 				break
 			}
-			slot := radixSlot(key, rnode.level)
+			slot := radixSlot(key, rnode.level, rtrie.width)
 			node = rnode.children[slot]
 		}
 	}
-	return nil, false
+	var value V
+	return value, false
 }
 
-func (rtrie *radixTrie) Set(key uint64, value interface{}) (interface{}, bool) {
+func (rtrie *radixTrie[V, C]) Set(key uint64, value V) (V, bool) {
 	if rtrie.root == nil {
-		rtrie.root = &radixLeaf{key, value}
-		return nil, false
+		rtrie.root = &radixLeaf[V]{key, value, rtrie.gen}
+		rtrie.size++
+		var origValue V
+		return origValue, false
 	}
 
 	node := rtrie.root
 	parent := &rtrie.root
 
 	for {
-		if leaf, ok := node.(*radixLeaf); ok {
+		if leaf, ok := node.(*radixLeaf[V]); ok {
 			if leaf.key == key {
 				origValue := leaf.value
-				leaf.value = value
+				if leaf.gen == rtrie.gen {
+					leaf.value = value
+				} else {
+					*parent = &radixLeaf[V]{key, value, rtrie.gen}
+				}
 				return origValue, true
 			}
-			level := radixDiffLevel(key, leaf.key)
-			newKey := radixTrimKey(key, level+1)
-			node := newRadixNode(newKey, level, 2)
-			node.setChild(key, &radixLeaf{key, value})
-			node.setChild(leaf.key, leaf)
+			level := radixDiffLevel(key, leaf.key, rtrie.width)
+			newKey := radixTrimKey(key, level+1, rtrie.width)
+			node := newRadixNode[V, C](newKey, level, 2, rtrie.gen)
+			node.setChild(key, &radixLeaf[V]{key, value, rtrie.gen}, rtrie.width)
+			node.setChild(leaf.key, leaf, rtrie.width)
 			*parent = node
-			return nil, false
+			rtrie.size++
+			var origValue V
+			return origValue, false
 		} else {
-			rnode := node.(*radixNode)
-			if rnode.notDescendant(key) {
-				level := radixDiffLevel(key, rnode.key)
-				newKey := radixTrimKey(key, level+1)
-				node := newRadixNode(newKey, level, 2)
-				node.setChild(key, &radixLeaf{key, value})
-				node.setChild(rnode.key, rnode)
+			rnode := node.(*radixNode[V, C])
+			if rnode.notDescendant(key, rtrie.width, rtrie.limit) {
+				level := radixDiffLevel(key, rnode.key, rtrie.width)
+				newKey := radixTrimKey(key, level+1, rtrie.width)
+				node := newRadixNode[V, C](newKey, level, 2, rtrie.gen)
+				node.setChild(key, &radixLeaf[V]{key, value, rtrie.gen}, rtrie.width)
+				node.setChild(rnode.key, rnode, rtrie.width)
 				*parent = node
-				return nil, false
+				rtrie.size++
+				var origValue V
+				return origValue, false
 			}
-			slot := radixSlot(key, rnode.level)
+			rnode = rtrie.own(rnode, parent)
+			slot := radixSlot(key, rnode.level, rtrie.width)
 			if rnode.children[slot] == nil {
-				rnode.children[slot] = &radixLeaf{key, value}
+				rnode.children[slot] = &radixLeaf[V]{key, value, rtrie.gen}
 				rnode.count++
-				return nil, false
+				rtrie.size++
+				var origValue V
+				return origValue, false
 			}
 			parent = &rnode.children[slot]
 			node = rnode.children[slot]
@@ -103,103 +213,255 @@ func (rtrie *radixTrie) Set(key uint64, value interface{}) (interface{}, bool) {
 	}
 }
 
-func (rtrie *radixTrie) Del(key uint64) (interface{}, bool) {
+func (rtrie *radixTrie[V, C]) Del(key uint64) (V, bool) {
 	if rtrie.root == nil {
-		return nil, false
+		var value V
+		return value, false
 	}
 
-	if leaf, ok := rtrie.root.(*radixLeaf); ok {
+	if leaf, ok := rtrie.root.(*radixLeaf[V]); ok {
 		if leaf.key == key {
 			rtrie.root = nil
+			rtrie.size--
 			return leaf.value, true
 		} else {
-			return nil, false
+			var value V
+			return value, false
 		}
 	}
 
-	var parent *radixNode
+	// Find the nodes on the path to the leaf before modifying anything so that
+	// nothing is copied if the key is not in the trie.
+	var path [radixMaxLevels]*radixNode[V, C]
+	depth := 0
 	node := rtrie.root
 
 	for node != nil {
-		rnode := node.(*radixNode)
-		slot := radixSlot(key, rnode.level)
+		rnode := node.(*radixNode[V, C])
+		path[depth] = rnode
+		depth++
+		slot := radixSlot(key, rnode.level, rtrie.width)
 		child := rnode.children[slot]
-		if leaf, ok := child.(*radixLeaf); ok {
+		if leaf, ok := child.(*radixLeaf[V]); ok {
 			if leaf.key != key {
 				break
 			}
+
+			ref := &rtrie.root
+			for i := 0; i < depth; i++ {
+				path[i] = rtrie.own(path[i], ref)
+				ref = &path[i].children[radixSlot(key, path[i].level, rtrie.width)]
+			}
+			rnode = path[depth-1]
+			var parent *radixNode[V, C]
+			if depth > 1 {
+				parent = path[depth-2]
+			}
+
 			rnode.children[slot] = nil
 			rnode.count--
+			rtrie.size--
 			if rnode.count > 1 {
 				return leaf.value, true
 			}
 			i := 0
-			for ; i < RADIX_COUNT; i++ {
+			for ; i < len(rnode.children); i++ {
 				if rnode.children[i] != nil {
 					break
 				}
 			}
-			if i == RADIX_COUNT {
+			if i == len(rnode.children) {
 				panic("incorrect count in radix trie")
 			}
 			if parent == nil {
 				rtrie.root = rnode.children[i]
 			} else {
-				parent.setChild(key, rnode.children[i])
+				parent.setChild(key, rnode.children[i], rtrie.width)
 			}
 			return leaf.value, true
 		} else {
-			parent = rnode
 			node = child
 		}
 	}
 
-	return nil, false
+	var value V
+	return value, false
+}
+
+func (rtrie *radixTrie[V, C]) Snapshot() TrieOf[V] {
+	snapshot := &radixTrie[V, C]{rtrie.root, rtrie.size, rtrie.gen, rtrie.width, rtrie.limit}
+	rtrie.gen++
+	return radixSnapshot[V, C]{snapshot}
+}
+
+// own returns the given node if it belongs to the current generation of the
+// trie. Otherwise, it returns a copy which does and replaces the node with it
+// in the given parent slot.
+func (rtrie *radixTrie[V, C]) own(rnode *radixNode[V, C], parent *radixTrieNode) *radixNode[V, C] {
+	if rnode.gen == rtrie.gen {
+		return rnode
+	}
+	copied := *rnode
+	copied.gen = rtrie.gen
+	*parent = &copied
+	return &copied
+}
+
+func (snapshot radixSnapshot[V, C]) Set(key uint64, value V) (V, bool) {
+	panic("radix trie snapshot is read-only")
+}
+
+func (snapshot radixSnapshot[V, C]) Del(key uint64) (V, bool) {
+	panic("radix trie snapshot is read-only")
 }
 
-func newRadixNode(key uint64, level uint, count uint) *radixNode {
-	return &radixNode{key: key, level: level, count: count}
+func (snapshot radixSnapshot[V, C]) Snapshot() TrieOf[V] {
+	return snapshot
+}
+
+func (rtrie *radixTrie[V, C]) Ascend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		rtrie.walk(0, math.MaxUint64, false, yield)
+	}
+}
+
+func (rtrie *radixTrie[V, C]) Descend() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		rtrie.walk(0, math.MaxUint64, true, yield)
+	}
+}
+
+func (rtrie *radixTrie[V, C]) Range(lo, hi uint64) iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		if lo < hi {
+			rtrie.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (rtrie *radixTrie[V, C]) Min() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).min()
+}
+
+func (rtrie *radixTrie[V, C]) Max() (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).max()
+}
+
+func (rtrie *radixTrie[V, C]) Floor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).floor(key)
+}
+
+func (rtrie *radixTrie[V, C]) Ceiling(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).ceiling(key)
+}
+
+func (rtrie *radixTrie[V, C]) Predecessor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).predecessor(key)
+}
+
+func (rtrie *radixTrie[V, C]) Successor(key uint64) (uint64, V, bool) {
+	return trieWalkFunc[uint64, V](rtrie.walk).successor(key)
+}
+
+func (rtrie *radixTrie[V, C]) Len() int {
+	return rtrie.size
+}
+
+func (rtrie *radixTrie[V, C]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	trieWalkFunc[uint64, V](rtrie.walk).prefix(prefix, bits, fn)
+}
+
+func (rtrie *radixTrie[V, C]) CountPrefix(prefix uint64, bits uint) int {
+	return trieWalkFunc[uint64, V](rtrie.walk).countPrefix(prefix, bits)
+}
+
+func (rtrie *radixTrie[V, C]) walk(lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	return radixWalk[V, C](rtrie.root, rtrie.width, lo, hi, reverse, yield)
+}
+
+// radixWalk calls yield on every leaf underneath the given node in a trie of the
+// given width with a key between lo and hi, inclusive, in ascending order of
+// keys, or descending order if reverse is true. Subtrees outside of the range
+// are skipped. It returns false if yield returned false.
+func radixWalk[V any, C radixChildren](node radixTrieNode, width uint, lo, hi uint64, reverse bool, yield func(uint64, V) bool) bool {
+	switch node := node.(type) {
+	case *radixLeaf[V]:
+		if node.key < lo || node.key > hi {
+			return true
+		}
+		return yield(node.key, node.value)
+	case *radixNode[V, C]:
+		if node.last(width) < lo || node.key > hi {
+			return true
+		}
+		for i := 0; i < len(node.children); i++ {
+			slot := i
+			if reverse {
+				slot = len(node.children) - 1 - i
+			}
+			if !radixWalk[V, C](node.children[slot], width, lo, hi, reverse, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func newRadixNode[V any, C radixChildren](key uint64, level uint, count uint, gen uint64) *radixNode[V, C] {
+	return &radixNode[V, C]{key: key, level: level, count: count, gen: gen}
 }
 
 // radixSlot returns the index into the children array of the radix node for
-// the given key.
-func radixSlot(key uint64, level uint) int {
-	return int((key >> (level * RADIX_WIDTH)) & RADIX_MASK)
+// the given key in a trie of the given width.
+func radixSlot(key uint64, level uint, width uint) int {
+	return int((key >> (level * width)) & (1<<width - 1))
 }
 
-// radixTrimKey trims a key after the given level.
-func radixTrimKey(key uint64, level uint) uint64 {
-	key >>= level * RADIX_WIDTH
-	key <<= level * RADIX_WIDTH
+// radixTrimKey trims a key after the given level in a trie of the given width.
+func radixTrimKey(key uint64, level uint, width uint) uint64 {
+	key >>= level * width
+	key <<= level * width
 	return key
 }
 
-// radixDiffLevel finds the highest level at which the keys differ.
-func radixDiffLevel(key1, key2 uint64) uint {
+// radixDiffLevel finds the highest level at which the keys differ in a trie of
+// the given width.
+func radixDiffLevel(key1, key2 uint64, width uint) uint {
 	if key1 == key2 {
 		panic("equal keys")
 	}
 
 	key := key1 ^ key2
-	for level := uint(RADIX_LIMIT); ; level-- {
-		if radixSlot(key, level) != 0 {
+	for level := radixLimit(width); ; level-- {
+		if radixSlot(key, level, width) != 0 {
 			return level
 		}
 	}
 }
 
+// radixLimit returns the highest level in a trie of the given width.
+func radixLimit(width uint) uint {
+	return (64+(width+1))/width - 1
+}
+
 // notDescendant returns true if the given key can be determined to not be
-// underneath the given node.
-func (rnode *radixNode) notDescendant(key uint64) bool {
-	if rnode.level < RADIX_LIMIT {
-		return radixTrimKey(key, rnode.level+1) != rnode.key
+// underneath the given node in a trie of the given width and highest level.
+func (rnode *radixNode[V, C]) notDescendant(key uint64, width, limit uint) bool {
+	if rnode.level < limit {
+		return radixTrimKey(key, rnode.level+1, width) != rnode.key
 	} else {
 		return false
 	}
 }
 
-// setChild adds this child in its slot.
-func (rnode *radixNode) setChild(key uint64, child radixTrieNode) {
-	slot := radixSlot(key, rnode.level)
+// last returns the largest key which could be underneath the given node in a
+// trie of the given width.
+func (rnode *radixNode[V, C]) last(width uint) uint64 {
+	return rnode.key | (1<<((rnode.level+1)*width) - 1)
+}
+
+// setChild adds this child in its slot in a trie of the given width.
+func (rnode *radixNode[V, C]) setChild(key uint64, child radixTrieNode, width uint) {
+	slot := radixSlot(key, rnode.level, width)
 	rnode.children[slot] = child
 }
@@ -0,0 +1,275 @@
+package tree
+
+// Path-compressed radix trie implementation with 128-bit keys.
+
+import (
+	"iter"
+)
+
+// Highest level in a radix trie with 128-bit keys.
+const radix128Limit = (128+RADIX_WIDTH-1)/RADIX_WIDTH - 1
+
+// Radix trie with 128-bit keys.
+type radixTrie128[V any] struct {
+	root radixTrieNode
+
+	// Number of keys in the trie.
+	size int
+}
+
+// Node in a radix trie with 128-bit keys.
+type radixNode128[V any] struct {
+	key      Uint128
+	level    uint
+	count    uint
+	children [RADIX_COUNT]radixTrieNode
+}
+
+// Leaf containing a value in a radix trie with 128-bit keys.
+type radixLeaf128[V any] struct {
+	key   Uint128
+	value V
+}
+
+// NewRadixTrie128 creates an empty path-compressed radix trie with Uint128
+// keys. It indexes RADIX_WIDTH bits at each level, like NewRadixTrie, but has
+// twice as many levels, and nodes are only created where keys diverge.
+func NewRadixTrie128() Trie128 {
+	return NewRadixTrie128Of[interface{}]()
+}
+
+// NewRadixTrie128Of creates an empty path-compressed radix trie with Uint128
+// keys and values of type V. See NewRadixTrie128.
+func NewRadixTrie128Of[V any]() Trie128Of[V] {
+	return new(radixTrie128[V])
+}
+
+func (rtrie *radixTrie128[V]) Get(key Uint128) (V, bool) {
+	node := rtrie.root
+
+	for node != nil {
+		if leaf, ok := node.(*radixLeaf128[V]); ok {
+			if leaf.key == key {
+				return leaf.value, true
+			} else {
+				break
+			}
+		} else {
+			rnode := node.(*radixNode128[V])
+			if rnode.notDescendant(key) {
+				break
+			}
+			node = rnode.children[radix128Slot(key, rnode.level)]
+		}
+	}
+	var value V
+	return value, false
+}
+
+func (rtrie *radixTrie128[V]) Set(key Uint128, value V) (V, bool) {
+	if rtrie.root == nil {
+		rtrie.root = &radixLeaf128[V]{key, value}
+		rtrie.size++
+		var origValue V
+		return origValue, false
+	}
+
+	node := rtrie.root
+	parent := &rtrie.root
+
+	for {
+		var otherKey Uint128
+		if leaf, ok := node.(*radixLeaf128[V]); ok {
+			if leaf.key == key {
+				origValue := leaf.value
+				leaf.value = value
+				return origValue, true
+			}
+			otherKey = leaf.key
+		} else {
+			rnode := node.(*radixNode128[V])
+			if !rnode.notDescendant(key) {
+				slot := radix128Slot(key, rnode.level)
+				if rnode.children[slot] == nil {
+					rnode.children[slot] = &radixLeaf128[V]{key, value}
+					rnode.count++
+					rtrie.size++
+					var origValue V
+					return origValue, false
+				}
+				parent = &rnode.children[slot]
+				node = rnode.children[slot]
+				continue
+			}
+			otherKey = rnode.key
+		}
+
+		// The key diverges from the node, so add a node at the level where
+		// they differ with both of them as children.
+		level := radix128DiffLevel(key, otherKey)
+		rnode := &radixNode128[V]{key: radix128TrimKey(key, level+1), level: level, count: 2}
+		rnode.children[radix128Slot(key, level)] = &radixLeaf128[V]{key, value}
+		rnode.children[radix128Slot(otherKey, level)] = node
+		*parent = rnode
+		rtrie.size++
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (rtrie *radixTrie128[V]) Del(key Uint128) (V, bool) {
+	var parent *radixTrieNode
+	ref := &rtrie.root
+
+	for *ref != nil {
+		if leaf, ok := (*ref).(*radixLeaf128[V]); ok {
+			if leaf.key != key {
+				break
+			}
+			*ref = nil
+			rtrie.size--
+
+			// Replace a node left with only one child by that child.
+			if parent != nil {
+				rnode := (*parent).(*radixNode128[V])
+				rnode.count--
+				if rnode.count == 1 {
+					for _, child := range rnode.children {
+						if child != nil {
+							*parent = child
+							break
+						}
+					}
+				}
+			}
+			return leaf.value, true
+		}
+
+		rnode := (*ref).(*radixNode128[V])
+		if rnode.notDescendant(key) {
+			break
+		}
+		parent = ref
+		ref = &rnode.children[radix128Slot(key, rnode.level)]
+	}
+
+	var value V
+	return value, false
+}
+
+func (rtrie *radixTrie128[V]) Ascend() iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		rtrie.walk(Uint128{}, maxUint128, false, yield)
+	}
+}
+
+func (rtrie *radixTrie128[V]) Descend() iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		rtrie.walk(Uint128{}, maxUint128, true, yield)
+	}
+}
+
+func (rtrie *radixTrie128[V]) Range(lo, hi Uint128) iter.Seq2[Uint128, V] {
+	return func(yield func(Uint128, V) bool) {
+		trieWalkFunc[Uint128, V](rtrie.walk).rangeSeq(lo, hi, yield)
+	}
+}
+
+func (rtrie *radixTrie128[V]) Min() (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).min()
+}
+
+func (rtrie *radixTrie128[V]) Max() (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).max()
+}
+
+func (rtrie *radixTrie128[V]) Floor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).floor(key)
+}
+
+func (rtrie *radixTrie128[V]) Ceiling(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).ceiling(key)
+}
+
+func (rtrie *radixTrie128[V]) Predecessor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).predecessor(key)
+}
+
+func (rtrie *radixTrie128[V]) Successor(key Uint128) (Uint128, V, bool) {
+	return trieWalkFunc[Uint128, V](rtrie.walk).successor(key)
+}
+
+func (rtrie *radixTrie128[V]) Len() int {
+	return rtrie.size
+}
+
+func (rtrie *radixTrie128[V]) WalkPrefix(prefix Uint128, bits uint, fn func(Uint128, V) bool) {
+	trieWalkFunc[Uint128, V](rtrie.walk).prefix(prefix, bits, fn)
+}
+
+func (rtrie *radixTrie128[V]) CountPrefix(prefix Uint128, bits uint) int {
+	return trieWalkFunc[Uint128, V](rtrie.walk).countPrefix(prefix, bits)
+}
+
+func (rtrie *radixTrie128[V]) walk(lo, hi Uint128, reverse bool, yield func(Uint128, V) bool) bool {
+	return radix128Walk(rtrie.root, lo, hi, reverse, yield)
+}
+
+// radix128Walk is the 128-bit counterpart of radixWalk.
+func radix128Walk[V any](node radixTrieNode, lo, hi Uint128, reverse bool, yield func(Uint128, V) bool) bool {
+	switch node := node.(type) {
+	case *radixLeaf128[V]:
+		if node.key.Compare(lo) < 0 || node.key.Compare(hi) > 0 {
+			return true
+		}
+		return yield(node.key, node.value)
+	case *radixNode128[V]:
+		if node.last().Compare(lo) < 0 || node.key.Compare(hi) > 0 {
+			return true
+		}
+		for i := range node.children {
+			slot := i
+			if reverse {
+				slot = RADIX_MASK - i
+			}
+			if !radix128Walk(node.children[slot], lo, hi, reverse, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// radix128Slot returns the index into the children array of the radix node for
+// the given key.
+func radix128Slot(key Uint128, level uint) int {
+	return int(key.rsh(level*RADIX_WIDTH).Lo & RADIX_MASK)
+}
+
+// radix128TrimKey trims a key after the given level.
+func radix128TrimKey(key Uint128, level uint) Uint128 {
+	return key.rsh(level * RADIX_WIDTH).lsh(level * RADIX_WIDTH)
+}
+
+// radix128DiffLevel finds the highest level at which the keys differ.
+func radix128DiffLevel(key1, key2 Uint128) uint {
+	if key1 == key2 {
+		panic("equal keys")
+	}
+	return (127 - key1.xor(key2).leadingZeros()) / RADIX_WIDTH
+}
+
+// notDescendant returns true if the given key can be determined to not be
+// underneath the given node.
+func (rnode *radixNode128[V]) notDescendant(key Uint128) bool {
+	if rnode.level < radix128Limit {
+		return radix128TrimKey(key, rnode.level+1) != rnode.key
+	} else {
+		return false
+	}
+}
+
+// last returns the largest key which could be underneath the given node.
+func (rnode *radixNode128[V]) last() Uint128 {
+	return rnode.key.or(maxUint128.rsh(128 - (rnode.level+1)*RADIX_WIDTH))
+}
@@ -0,0 +1,280 @@
+package tree
+
+// Path-compressed radix trie implementation with 32-bit keys.
+
+import (
+	"iter"
+	"math"
+)
+
+// Highest level in a radix trie with 32-bit keys.
+const radix32Limit = (32+RADIX_WIDTH-1)/RADIX_WIDTH - 1
+
+// Radix trie with 32-bit keys.
+type radixTrie32[V any] struct {
+	root radixTrieNode
+
+	// Number of keys in the trie.
+	size int
+}
+
+// Node in a radix trie with 32-bit keys.
+type radixNode32[V any] struct {
+	key      uint32
+	level    uint
+	count    uint
+	children [RADIX_COUNT]radixTrieNode
+}
+
+// Leaf containing a value in a radix trie with 32-bit keys.
+type radixLeaf32[V any] struct {
+	key   uint32
+	value V
+}
+
+// NewRadixTrie32 creates an empty path-compressed radix trie with uint32 keys.
+// It indexes RADIX_WIDTH bits at each level, like NewRadixTrie, but has less
+// than half as many levels.
+func NewRadixTrie32() Trie32 {
+	return NewRadixTrie32Of[interface{}]()
+}
+
+// NewRadixTrie32Of creates an empty path-compressed radix trie with uint32
+// keys and values of type V. See NewRadixTrie32.
+func NewRadixTrie32Of[V any]() Trie32Of[V] {
+	return new(radixTrie32[V])
+}
+
+func (rtrie *radixTrie32[V]) Get(key uint32) (V, bool) {
+	node := rtrie.root
+
+	for node != nil {
+		if leaf, ok := node.(*radixLeaf32[V]); ok {
+			if leaf.key == key {
+				return leaf.value, true
+			} else {
+				break
+			}
+		} else {
+			rnode := node.(*radixNode32[V])
+			if rnode.notDescendant(key) {
+				break
+			}
+			node = rnode.children[radix32Slot(key, rnode.level)]
+		}
+	}
+	var value V
+	return value, false
+}
+
+func (rtrie *radixTrie32[V]) Set(key uint32, value V) (V, bool) {
+	if rtrie.root == nil {
+		rtrie.root = &radixLeaf32[V]{key, value}
+		rtrie.size++
+		var origValue V
+		return origValue, false
+	}
+
+	node := rtrie.root
+	parent := &rtrie.root
+
+	for {
+		var otherKey uint32
+		if leaf, ok := node.(*radixLeaf32[V]); ok {
+			if leaf.key == key {
+				origValue := leaf.value
+				leaf.value = value
+				return origValue, true
+			}
+			otherKey = leaf.key
+		} else {
+			rnode := node.(*radixNode32[V])
+			if !rnode.notDescendant(key) {
+				slot := radix32Slot(key, rnode.level)
+				if rnode.children[slot] == nil {
+					rnode.children[slot] = &radixLeaf32[V]{key, value}
+					rnode.count++
+					rtrie.size++
+					var origValue V
+					return origValue, false
+				}
+				parent = &rnode.children[slot]
+				node = rnode.children[slot]
+				continue
+			}
+			otherKey = rnode.key
+		}
+
+		// The key diverges from the node, so add a node at the level where
+		// they differ with both of them as children.
+		level := radix32DiffLevel(key, otherKey)
+		rnode := &radixNode32[V]{key: radix32TrimKey(key, level+1), level: level, count: 2}
+		rnode.children[radix32Slot(key, level)] = &radixLeaf32[V]{key, value}
+		rnode.children[radix32Slot(otherKey, level)] = node
+		*parent = rnode
+		rtrie.size++
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (rtrie *radixTrie32[V]) Del(key uint32) (V, bool) {
+	var parent *radixTrieNode
+	ref := &rtrie.root
+
+	for *ref != nil {
+		if leaf, ok := (*ref).(*radixLeaf32[V]); ok {
+			if leaf.key != key {
+				break
+			}
+			*ref = nil
+			rtrie.size--
+
+			// Replace a node left with only one child by that child.
+			if parent != nil {
+				rnode := (*parent).(*radixNode32[V])
+				rnode.count--
+				if rnode.count == 1 {
+					for _, child := range rnode.children {
+						if child != nil {
+							*parent = child
+							break
+						}
+					}
+				}
+			}
+			return leaf.value, true
+		}
+
+		rnode := (*ref).(*radixNode32[V])
+		if rnode.notDescendant(key) {
+			break
+		}
+		parent = ref
+		ref = &rnode.children[radix32Slot(key, rnode.level)]
+	}
+
+	var value V
+	return value, false
+}
+
+func (rtrie *radixTrie32[V]) Ascend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		rtrie.walk(0, math.MaxUint32, false, yield)
+	}
+}
+
+func (rtrie *radixTrie32[V]) Descend() iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		rtrie.walk(0, math.MaxUint32, true, yield)
+	}
+}
+
+func (rtrie *radixTrie32[V]) Range(lo, hi uint32) iter.Seq2[uint32, V] {
+	return func(yield func(uint32, V) bool) {
+		if lo < hi {
+			rtrie.walk(lo, hi-1, false, yield)
+		}
+	}
+}
+
+func (rtrie *radixTrie32[V]) Min() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).min()
+}
+
+func (rtrie *radixTrie32[V]) Max() (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).max()
+}
+
+func (rtrie *radixTrie32[V]) Floor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).floor(key)
+}
+
+func (rtrie *radixTrie32[V]) Ceiling(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).ceiling(key)
+}
+
+func (rtrie *radixTrie32[V]) Predecessor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).predecessor(key)
+}
+
+func (rtrie *radixTrie32[V]) Successor(key uint32) (uint32, V, bool) {
+	return trieWalkFunc[uint32, V](rtrie.walk).successor(key)
+}
+
+func (rtrie *radixTrie32[V]) Len() int {
+	return rtrie.size
+}
+
+func (rtrie *radixTrie32[V]) WalkPrefix(prefix uint32, bits uint, fn func(uint32, V) bool) {
+	trieWalkFunc[uint32, V](rtrie.walk).prefix(prefix, bits, fn)
+}
+
+func (rtrie *radixTrie32[V]) CountPrefix(prefix uint32, bits uint) int {
+	return trieWalkFunc[uint32, V](rtrie.walk).countPrefix(prefix, bits)
+}
+
+func (rtrie *radixTrie32[V]) walk(lo, hi uint32, reverse bool, yield func(uint32, V) bool) bool {
+	return radix32Walk(rtrie.root, lo, hi, reverse, yield)
+}
+
+// radix32Walk is the 32-bit counterpart of radixWalk.
+func radix32Walk[V any](node radixTrieNode, lo, hi uint32, reverse bool, yield func(uint32, V) bool) bool {
+	switch node := node.(type) {
+	case *radixLeaf32[V]:
+		if node.key < lo || node.key > hi {
+			return true
+		}
+		return yield(node.key, node.value)
+	case *radixNode32[V]:
+		if node.last() < lo || node.key > hi {
+			return true
+		}
+		for i := range node.children {
+			slot := i
+			if reverse {
+				slot = RADIX_MASK - i
+			}
+			if !radix32Walk(node.children[slot], lo, hi, reverse, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// radix32Slot returns the index into the children array of the radix node for
+// the given key.
+func radix32Slot(key uint32, level uint) int {
+	return int((key >> (level * RADIX_WIDTH)) & RADIX_MASK)
+}
+
+// radix32TrimKey trims a key after the given level.
+func radix32TrimKey(key uint32, level uint) uint32 {
+	key >>= level * RADIX_WIDTH
+	key <<= level * RADIX_WIDTH
+	return key
+}
+
+// radix32DiffLevel finds the highest level at which the keys differ.
+func radix32DiffLevel(key1, key2 uint32) uint {
+	if key1 == key2 {
+		panic("equal keys")
+	}
+	return uint(31-clz32(key1^key2)) / RADIX_WIDTH
+}
+
+// notDescendant returns true if the given key can be determined to not be
+// underneath the given node.
+func (rnode *radixNode32[V]) notDescendant(key uint32) bool {
+	if rnode.level < radix32Limit {
+		return radix32TrimKey(key, rnode.level+1) != rnode.key
+	} else {
+		return false
+	}
+}
+
+// last returns the largest key which could be underneath the given node.
+func (rnode *radixNode32[V]) last() uint32 {
+	return rnode.key | (math.MaxUint32 >> (32 - (rnode.level+1)*RADIX_WIDTH))
+}
@@ -0,0 +1,144 @@
+package tree
+
+import (
+	"sync"
+	"testing"
+)
+
+// checkTrieContents checks that a trie contains exactly the keys and values in
+// the given map. It may be called from any goroutine.
+func checkTrieContents(t *testing.T, trie TrieOf[int], m map[uint64]int) {
+	if n := trie.Len(); n != len(m) {
+		t.Errorf("len failed: got %v, expected %v\n", n, len(m))
+		return
+	}
+	n := 0
+	for k, v := range trie.Ascend() {
+		if w, ok := m[k]; !ok || v != w {
+			t.Errorf("ascend failed: got %v for %v, expected %v, %v\n", v, k, w, ok)
+			return
+		}
+		n++
+	}
+	if n != len(m) {
+		t.Errorf("ascend failed: visited %v keys, expected %v\n", n, len(m))
+		return
+	}
+	for k, w := range m {
+		if v, ok := trie.Get(k); !ok || v != w {
+			t.Errorf("get failed: got %v, %v for %v, expected %v\n", v, ok, k, w)
+			return
+		}
+	}
+}
+
+func TestRadixTrieSnapshot(t *testing.T) {
+	const numSnapshots = 20
+
+	rtrie := NewRadixTrieOf[int]()
+	m := map[uint64]int{}
+	var snapshots []TrieOf[int]
+	var maps []map[uint64]int
+
+	for i := 0; i < numSnapshots; i++ {
+		snapshots = append(snapshots, rtrie.Snapshot())
+		copied := make(map[uint64]int, len(m))
+		for k, v := range m {
+			copied[k] = v
+		}
+		maps = append(maps, copied)
+
+		for j := 0; j < NUM_NODES/numSnapshots; j++ {
+			k := uint64(testRand.Intn(NUM_NODES / 4))
+			if testRand.Intn(3) == 0 {
+				rtrie.Del(k)
+				delete(m, k)
+			} else {
+				v := testRand.Int()
+				rtrie.Set(k, v)
+				m[k] = v
+			}
+		}
+	}
+
+	checkTrieContents(t, rtrie, m)
+	for i := range snapshots {
+		checkTrieContents(t, snapshots[i], maps[i])
+	}
+}
+
+func TestRadixTrieSnapshotReadOnly(t *testing.T) {
+	snapshot := NewRadixTrie().Snapshot()
+	for _, f := range []func(){
+		func() { snapshot.Set(0, 0) },
+		func() { snapshot.Del(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("modifying snapshot did not panic\n")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestRadixTrieSnapshotConcurrent(t *testing.T) {
+	rtrie := NewRadixTrieOf[int]()
+	m := map[uint64]int{}
+	for _, v := range testRand.Perm(NUM_NODES) {
+		rtrie.Set(uint64(v), v)
+		m[uint64(v)] = v
+	}
+	snapshot := rtrie.Snapshot()
+
+	// Readers of the snapshot must not observe the writer.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkTrieContents(t, snapshot, m)
+		}()
+	}
+	for _, v := range testRand.Perm(2 * NUM_NODES) {
+		if v%2 == 0 {
+			rtrie.Del(uint64(v))
+		} else {
+			rtrie.Set(uint64(v), -v)
+		}
+	}
+	wg.Wait()
+}
+
+func TestRadixTrieWidthSnapshot(t *testing.T) {
+	for _, width := range []uint{1, 3, 5, 8} {
+		rtrie := NewRadixTrieWidthOf[int](width)
+		m := map[uint64]int{}
+		for i := 0; i < NUM_NODES/10; i++ {
+			k := testRand.Uint64()
+			rtrie.Set(k, i)
+			m[k] = i
+		}
+		snapshot := rtrie.Snapshot()
+		for k := range m {
+			rtrie.Del(k)
+		}
+		checkTrieContents(t, snapshot, m)
+		checkTrieContents(t, rtrie, map[uint64]int{})
+	}
+}
+
+func TestRadixTrieWidthInvalid(t *testing.T) {
+	for _, width := range []uint{0, 9, 64} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("width %v did not panic\n", width)
+				}
+			}()
+			NewRadixTrieWidth(width)
+		}()
+	}
+}
@@ -0,0 +1,224 @@
+package tree
+
+// Red-black tree implementation.
+
+import (
+	"cmp"
+)
+
+// Colors of red-black tree nodes. New nodes are red.
+const (
+	rbRed   = 0
+	rbBlack = 1
+)
+
+// Red-black tree.
+type redBlackTree[K, V any] struct {
+	// Underlying binary search tree. The balance of each node is its color.
+	binarySearchTree[K, V]
+}
+
+// NewRedBlack creates an empty red-black tree. A red-black tree is a
+// self-balancing binary search tree which colors each node red or black such
+// that every path from the root to a leaf has the same number of black nodes
+// and no red node has a red child. All operations are O(log n) in the worst
+// case, and insertion and deletion do at most a constant number of rotations.
+// The returned tree is an OrderStatisticTree.
+func NewRedBlack() Tree {
+	return NewRedBlackOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewRedBlackOf creates an empty red-black tree ordered by the natural order of
+// its keys. See NewRedBlack.
+func NewRedBlackOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewRedBlackOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewRedBlackOfFunc creates an empty red-black tree ordered by the given
+// comparison function. See NewRedBlack and NewBSTOfFunc.
+func NewRedBlackOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	rb := new(redBlackTree[K, V])
+	rb.compare = compare
+	return rb
+}
+
+// NewRedBlackFunc creates an empty red-black tree ordered by the given
+// comparison function. See NewRedBlack and NewBSTFunc.
+func NewRedBlackFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewRedBlackOfFunc[interface{}, interface{}](compare)
+}
+
+func (rb *redBlackTree[K, V]) Set(key K, value V) (V, bool) {
+	node, exists := rb.add(key)
+
+	if exists {
+		origValue := node.value
+		node.value = value
+		return origValue, true
+	} else {
+		node.value = value
+		rb.insertFixup(node)
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (rb *redBlackTree[K, V]) Del(key K) (V, bool) {
+	node := rb.get(key)
+	if node == nil {
+		var value V
+		return value, false
+	}
+
+	// Find the node which will take the place of the removed node's color and
+	// the parent of the node which will take its place. This mirrors the
+	// choice of successor in remove.
+	var successor, child, parent *bstNode[K, V]
+	removedColor := node.balance
+	if node.left != nil && node.right != nil {
+		successor = node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		removedColor = successor.balance
+		child = successor.right
+		if successor.parent == node {
+			parent = successor
+		} else {
+			parent = successor.parent
+		}
+	} else {
+		if node.left != nil {
+			child = node.left
+		} else {
+			child = node.right
+		}
+		parent = node.parent
+	}
+
+	rb.remove(node)
+	if successor != nil {
+		successor.balance = node.balance
+	}
+
+	if removedColor == rbBlack {
+		rb.deleteFixup(child, parent)
+	}
+	return node.value, true
+}
+
+// insertFixup restores the red-black properties after inserting the given red
+// node.
+func (rb *redBlackTree[K, V]) insertFixup(node *bstNode[K, V]) {
+	for node.parent.isRed() {
+		// The parent is red, so it is not the root.
+		parent := node.parent
+		grandparent := parent.parent
+
+		if parent == grandparent.left {
+			uncle := grandparent.right
+			if uncle.isRed() {
+				// Push the grandparent's blackness down and continue above.
+				parent.balance = rbBlack
+				uncle.balance = rbBlack
+				grandparent.balance = rbRed
+				node = grandparent
+				continue
+			}
+			if node == parent.right {
+				node = parent
+				rb.rotateLeft(node)
+				parent = node.parent
+			}
+			parent.balance = rbBlack
+			grandparent.balance = rbRed
+			rb.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+			if uncle.isRed() {
+				parent.balance = rbBlack
+				uncle.balance = rbBlack
+				grandparent.balance = rbRed
+				node = grandparent
+				continue
+			}
+			if node == parent.left {
+				node = parent
+				rb.rotateRight(node)
+				parent = node.parent
+			}
+			parent.balance = rbBlack
+			grandparent.balance = rbRed
+			rb.rotateLeft(grandparent)
+		}
+	}
+	rb.root.balance = rbBlack
+}
+
+// deleteFixup restores the red-black properties after removing a black node.
+// The given node, which may be nil, took the place of the removed node and has
+// an extra black; parent is its parent.
+func (rb *redBlackTree[K, V]) deleteFixup(node, parent *bstNode[K, V]) {
+	for node != rb.root && !node.isRed() {
+		if node == parent.left {
+			sibling := parent.right
+			if sibling.isRed() {
+				sibling.balance = rbBlack
+				parent.balance = rbRed
+				rb.rotateLeft(parent)
+				sibling = parent.right
+			}
+			if !sibling.left.isRed() && !sibling.right.isRed() {
+				// Move the extra black up.
+				sibling.balance = rbRed
+				node = parent
+				parent = node.parent
+				continue
+			}
+			if !sibling.right.isRed() {
+				sibling.left.balance = rbBlack
+				sibling.balance = rbRed
+				rb.rotateRight(sibling)
+				sibling = parent.right
+			}
+			sibling.balance = parent.balance
+			parent.balance = rbBlack
+			sibling.right.balance = rbBlack
+			rb.rotateLeft(parent)
+		} else {
+			sibling := parent.left
+			if sibling.isRed() {
+				sibling.balance = rbBlack
+				parent.balance = rbRed
+				rb.rotateRight(parent)
+				sibling = parent.left
+			}
+			if !sibling.left.isRed() && !sibling.right.isRed() {
+				sibling.balance = rbRed
+				node = parent
+				parent = node.parent
+				continue
+			}
+			if !sibling.left.isRed() {
+				sibling.right.balance = rbBlack
+				sibling.balance = rbRed
+				rb.rotateLeft(sibling)
+				sibling = parent.left
+			}
+			sibling.balance = parent.balance
+			parent.balance = rbBlack
+			sibling.left.balance = rbBlack
+			rb.rotateRight(parent)
+		}
+		node = rb.root
+	}
+	if node != nil {
+		node.balance = rbBlack
+	}
+}
+
+// isRed returns whether this node, which may be nil, is red. Nil nodes are
+// black.
+func (node *bstNode[K, V]) isRed() bool {
+	return node != nil && node.balance == rbRed
+}
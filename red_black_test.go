@@ -0,0 +1,49 @@
+package tree
+
+import (
+	"testing"
+)
+
+// checkRedBlack checks the red-black properties of a subtree and returns its
+// black height.
+func checkRedBlack(t *testing.T, node *bstNode[Key, interface{}]) int {
+	if node == nil {
+		return 1
+	}
+
+	if node.isRed() && (node.left.isRed() || node.right.isRed()) {
+		t.Fatalf("red node %v has a red child\n", node.key)
+	}
+	left := checkRedBlack(t, node.left)
+	right := checkRedBlack(t, node.right)
+	if left != right {
+		t.Fatalf("unbalanced node %v: left black height %v, right black height %v\n",
+			node.key, left, right)
+	}
+	if node.isRed() {
+		return left
+	} else {
+		return left + 1
+	}
+}
+
+func TestRedBlackBalanced(t *testing.T) {
+	rb := NewRedBlack().(*redBlackTree[Key, interface{}])
+
+	// Sorted insertion degenerates an unbalanced tree into a list.
+	for i := 0; i < NUM_NODES; i++ {
+		rb.Set(Uint64Key(i), i)
+	}
+	if rb.root.isRed() {
+		t.Fatalf("red root\n")
+	}
+	checkRedBlack(t, rb.root)
+
+	for _, v := range testRand.Perm(NUM_NODES)[:NUM_NODES/2] {
+		rb.Del(Uint64Key(v))
+		if rb.root.isRed() {
+			t.Fatalf("red root\n")
+		}
+		checkRedBlack(t, rb.root)
+	}
+}
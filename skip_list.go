@@ -0,0 +1,279 @@
+package tree
+
+// Skip list implementation.
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+)
+
+const (
+	// Maximum number of levels in a skip list.
+	skipListMaxLevel = 32
+
+	// A node on one level is also on the next level with probability
+	// 1 / skipListFanout.
+	skipListFanout = 4
+)
+
+// Skip list.
+type skipList[K, V any] struct {
+	// Sentinel node before the first node on every level.
+	head skipListNode[K, V]
+
+	// Number of levels currently in use.
+	level int
+
+	// Number of nodes in the list.
+	size int
+
+	// Comparison function for keys.
+	compare func(K, K) int
+}
+
+// Node in a skip list.
+type skipListNode[K, V any] struct {
+	key   K
+	value V
+
+	// Previous node on the bottom level, or nil if this is the first node.
+	prev *skipListNode[K, V]
+
+	// Next node on each level that this node is on.
+	next []*skipListNode[K, V]
+}
+
+// NewSkipList creates an empty skip list. A skip list is a sorted linked list
+// with additional levels of randomly chosen express lanes, so all operations
+// are O(log n) expected. Unlike a splay tree, lookups do not modify the list,
+// and ordered iteration follows the bottom level.
+func NewSkipList() Tree {
+	return NewSkipListOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewSkipListOf creates an empty skip list ordered by the natural order of its
+// keys. See NewSkipList.
+func NewSkipListOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewSkipListOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewSkipListOfFunc creates an empty skip list ordered by the given comparison
+// function. See NewSkipList and NewBSTOfFunc.
+func NewSkipListOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	sl := &skipList[K, V]{level: 1, compare: compare}
+	sl.head.next = make([]*skipListNode[K, V], skipListMaxLevel)
+	return sl
+}
+
+// NewSkipListFunc creates an empty skip list ordered by the given comparison
+// function. See NewSkipList and NewBSTFunc.
+func NewSkipListFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewSkipListOfFunc[interface{}, interface{}](compare)
+}
+
+func (sl *skipList[K, V]) Get(key K) (V, bool) {
+	_, node := sl.search(key, nil)
+
+	if node == nil || sl.compare(node.key, key) != 0 {
+		var value V
+		return value, false
+	} else {
+		return node.value, true
+	}
+}
+
+func (sl *skipList[K, V]) Set(key K, value V) (V, bool) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	prev, next := sl.search(key, update[:])
+
+	if next != nil && sl.compare(next.key, key) == 0 {
+		origValue := next.value
+		next.key = key
+		next.value = value
+		return origValue, true
+	}
+
+	level := skipListRandomLevel()
+	for ; sl.level < level; sl.level++ {
+		update[sl.level] = &sl.head
+	}
+
+	node := &skipListNode[K, V]{key: key, value: value, next: make([]*skipListNode[K, V], level)}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	if prev != &sl.head {
+		node.prev = prev
+	}
+	if next != nil {
+		next.prev = node
+	}
+
+	sl.size++
+	var origValue V
+	return origValue, false
+}
+
+func (sl *skipList[K, V]) Del(key K) (V, bool) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	_, node := sl.search(key, update[:])
+
+	if node == nil || sl.compare(node.key, key) != 0 {
+		var value V
+		return value, false
+	}
+
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
+	}
+	for sl.level > 1 && sl.head.next[sl.level-1] == nil {
+		sl.level--
+	}
+
+	sl.size--
+	return node.value, true
+}
+
+func (sl *skipList[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.ascendTo(sl.head.next[0], nil, yield)
+	}
+}
+
+func (sl *skipList[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := sl.last(); node != nil; node = node.prev {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+func (sl *skipList[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		_, node := sl.search(lo, nil)
+		sl.ascendTo(node, &hi, yield)
+	}
+}
+
+func (sl *skipList[K, V]) Min() (K, V, bool) {
+	return sl.head.next[0].entry()
+}
+
+func (sl *skipList[K, V]) Max() (K, V, bool) {
+	return sl.last().entry()
+}
+
+func (sl *skipList[K, V]) Floor(key K) (K, V, bool) {
+	prev, next := sl.search(key, nil)
+
+	if next != nil && sl.compare(next.key, key) == 0 {
+		return next.entry()
+	} else {
+		return sl.real(prev).entry()
+	}
+}
+
+func (sl *skipList[K, V]) Ceiling(key K) (K, V, bool) {
+	_, next := sl.search(key, nil)
+	return next.entry()
+}
+
+func (sl *skipList[K, V]) Predecessor(key K) (K, V, bool) {
+	prev, _ := sl.search(key, nil)
+	return sl.real(prev).entry()
+}
+
+func (sl *skipList[K, V]) Successor(key K) (K, V, bool) {
+	_, next := sl.search(key, nil)
+
+	if next != nil && sl.compare(next.key, key) == 0 {
+		return next.next[0].entry()
+	} else {
+		return next.entry()
+	}
+}
+
+func (sl *skipList[K, V]) Len() int {
+	return sl.size
+}
+
+// search finds the last node with a key less than the given key, which may be
+// the head, and the node after it on the bottom level, which may be nil. If
+// update is not nil, it is filled in with the last node with a key less than
+// the given key on each level in use.
+func (sl *skipList[K, V]) search(key K, update []*skipListNode[K, V]) (*skipListNode[K, V], *skipListNode[K, V]) {
+	node := &sl.head
+
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+		if update != nil {
+			update[i] = node
+		}
+	}
+	return node, node.next[0]
+}
+
+// last finds the node with the largest key, or nil if the list is empty.
+func (sl *skipList[K, V]) last() *skipListNode[K, V] {
+	node := &sl.head
+
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+	return sl.real(node)
+}
+
+// real returns the given node, or nil if it is the head.
+func (sl *skipList[K, V]) real(node *skipListNode[K, V]) *skipListNode[K, V] {
+	if node == &sl.head {
+		return nil
+	} else {
+		return node
+	}
+}
+
+// skipListRandomLevel chooses the number of levels for a new node.
+func skipListRandomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Intn(skipListFanout) == 0 {
+		level++
+	}
+	return level
+}
+
+// ascendTo calls yield on the given node and the nodes following it until it
+// reaches a key greater than or equal to hi, or the end of the list if hi is
+// nil. The node may be nil.
+func (sl *skipList[K, V]) ascendTo(node *skipListNode[K, V], hi *K, yield func(K, V) bool) {
+	for ; node != nil; node = node.next[0] {
+		if hi != nil && sl.compare(node.key, *hi) >= 0 {
+			return
+		}
+		if !yield(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// entry returns the key and value of this node and true, or false if the node
+// is nil.
+func (node *skipListNode[K, V]) entry() (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	} else {
+		return node.key, node.value, true
+	}
+}
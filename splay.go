@@ -2,31 +2,76 @@ package tree
 
 // Splay tree implementation.
 
+import (
+	"cmp"
+	"iter"
+)
+
 // Splay tree.
-type splayTree struct {
+type splayTree[K, V any] struct {
 	// Underlying binary search tree.
-	bst binarySearchTree
+	bst binarySearchTree[K, V]
 }
 
 // NewSplay creates an empty splay tree. A splay tree is a self-adjusting
 // variant of a binary search tree that optimizes for locality of reference. It
-// has amortized O(log n) behavior in the worst case.
+// has amortized O(log n) behavior in the worst case. The returned tree is an
+// OrderStatisticTree; see NewOrderStatisticSplay.
 func NewSplay() Tree {
-	return new(splayTree)
+	return NewSplayOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewSplayOf creates an empty splay tree ordered by the natural order of its
+// keys. See NewSplay.
+func NewSplayOf[K cmp.Ordered, V any]() TreeOf[K, V] {
+	return NewSplayOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewSplayOfFunc creates an empty splay tree ordered by the given comparison
+// function. See NewSplay and NewBSTOfFunc.
+func NewSplayOfFunc[K, V any](compare func(K, K) int) TreeOf[K, V] {
+	return NewOrderStatisticSplayOfFunc[K, V](compare)
+}
+
+// NewSplayFunc creates an empty splay tree ordered by the given comparison
+// function. See NewSplay and NewBSTFunc.
+func NewSplayFunc(compare func(a, b interface{}) int) TreeOf[interface{}, interface{}] {
+	return NewSplayOfFunc[interface{}, interface{}](compare)
+}
+
+// NewOrderStatisticSplay creates an empty splay tree like NewSplay but returns
+// it as an OrderStatisticTree.
+func NewOrderStatisticSplay() OrderStatisticTree {
+	return NewOrderStatisticSplayOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewOrderStatisticSplayOf creates an empty splay tree like NewSplayOf but
+// returns it as an OrderStatisticTreeOf[K, V].
+func NewOrderStatisticSplayOf[K cmp.Ordered, V any]() OrderStatisticTreeOf[K, V] {
+	return NewOrderStatisticSplayOfFunc[K, V](cmp.Compare[K])
 }
 
-func (s *splayTree) Get(key Key) (interface{}, bool) {
+// NewOrderStatisticSplayOfFunc creates an empty splay tree like
+// NewSplayOfFunc but returns it as an OrderStatisticTreeOf[K, V].
+func NewOrderStatisticSplayOfFunc[K, V any](compare func(K, K) int) OrderStatisticTreeOf[K, V] {
+	s := new(splayTree[K, V])
+	s.bst.compare = compare
+	return s
+}
+
+func (s *splayTree[K, V]) Get(key K) (V, bool) {
 	node := s.bst.get(key)
 
 	if node == nil {
-		return nil, false
+		var value V
+		return value, false
 	} else {
 		s.splayNode(node)
 		return node.value, true
 	}
 }
 
-func (s *splayTree) Set(key Key, value interface{}) (interface{}, bool) {
+func (s *splayTree[K, V]) Set(key K, value V) (V, bool) {
 	node, exists := s.bst.add(key)
 
 	s.splayNode(node)
@@ -37,15 +82,17 @@ func (s *splayTree) Set(key Key, value interface{}) (interface{}, bool) {
 		return origValue, true
 	} else {
 		node.value = value
-		return nil, false
+		var origValue V
+		return origValue, false
 	}
 }
 
-func (s *splayTree) Del(key Key) (interface{}, bool) {
+func (s *splayTree[K, V]) Del(key K) (V, bool) {
 	node := s.bst.del(key)
 
 	if node == nil {
-		return nil, false
+		var value V
+		return value, false
 	} else {
 		if node.parent != nil {
 			s.splayNode(node.parent)
@@ -54,12 +101,82 @@ func (s *splayTree) Del(key Key) (interface{}, bool) {
 	}
 }
 
+// Ascend does not splay any nodes.
+func (s *splayTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return s.bst.Ascend()
+}
+
+// Descend does not splay any nodes.
+func (s *splayTree[K, V]) Descend() iter.Seq2[K, V] {
+	return s.bst.Descend()
+}
+
+// Range splays the first node in the range.
+func (s *splayTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.bst.ascendTo(s.splayFound(s.bst.nearest(lo, true, true)), hi, yield)
+	}
+}
+
+func (s *splayTree[K, V]) Min() (K, V, bool) {
+	return s.splayFound(s.bst.min()).entry()
+}
+
+func (s *splayTree[K, V]) Max() (K, V, bool) {
+	return s.splayFound(s.bst.max()).entry()
+}
+
+func (s *splayTree[K, V]) Floor(key K) (K, V, bool) {
+	return s.splayFound(s.bst.nearest(key, false, true)).entry()
+}
+
+func (s *splayTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return s.splayFound(s.bst.nearest(key, true, true)).entry()
+}
+
+func (s *splayTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return s.splayFound(s.bst.nearest(key, false, false)).entry()
+}
+
+func (s *splayTree[K, V]) Successor(key K) (K, V, bool) {
+	return s.splayFound(s.bst.nearest(key, true, false)).entry()
+}
+
+func (s *splayTree[K, V]) Len() int {
+	return s.bst.Len()
+}
+
+// Rank splays the last node visited.
+func (s *splayTree[K, V]) Rank(key K) int {
+	rank, last := s.bst.rank(key)
+	s.splayFound(last)
+	return rank
+}
+
+func (s *splayTree[K, V]) Select(i int) (K, V, bool) {
+	return s.splayFound(s.bst.nth(i)).entry()
+}
+
+// Lookups splay the tree.
+func (s *splayTree[K, V]) mutatesOnRead() bool {
+	return true
+}
+
+// splayFound splays a node returned by a search if it is not nil and returns
+// it.
+func (s *splayTree[K, V]) splayFound(node *bstNode[K, V]) *bstNode[K, V] {
+	if node != nil {
+		s.splayNode(node)
+	}
+	return node
+}
+
 // splayNode moves a node to the root of a tree in a manner that keeps recently
 // splayed elements near the root.
-func (s *splayTree) splayNode(node *bstNode) {
+func (s *splayTree[K, V]) splayNode(node *bstNode[K, V]) {
 	// Carry out splay steps until the node reaches the root.
 	for node != s.bst.root {
-		var parent, grandparent *bstNode
+		var parent, grandparent *bstNode[K, V]
 		parent = node.parent
 		if parent != nil {
 			grandparent = parent.parent
@@ -0,0 +1,351 @@
+package tree
+
+// Path-compressed radix tree with string keys.
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Radix tree with string keys and interface{} values.
+type StringRadixTree = StringRadixTreeOf[interface{}]
+
+// Radix tree with string keys and values of type V. Keys are ordered
+// lexicographically by bytes, and []byte keys can be used by converting them
+// to strings.
+type StringRadixTreeOf[V any] interface {
+	TreeOf[string, V]
+
+	// WalkPrefix calls fn on every key in the tree which begins with the
+	// given prefix, in ascending order of keys, until fn returns false. The
+	// tree must not be modified during the walk.
+	WalkPrefix(prefix string, fn func(string, V) bool)
+
+	// CountPrefix returns the number of keys in the tree which begin with the
+	// given prefix.
+	CountPrefix(prefix string) int
+
+	// LongestPrefix returns the longest key in the tree which is a prefix of
+	// the given key and its value. If there is no such key, it returns false.
+	LongestPrefix(string) (string, V, bool)
+}
+
+// String radix tree.
+type stringRadixTree[V any] struct {
+	// Root of the tree, whose key is the empty string.
+	root stringRadixNode[V]
+
+	// Number of keys in the tree.
+	size int
+}
+
+// Node in a string radix tree. Every key underneath a node begins with the
+// node's key. Every node other than the root either holds a key or has at
+// least two children.
+type stringRadixNode[V any] struct {
+	key   string
+	value V
+
+	// Whether the node's key is in the tree.
+	leaf bool
+
+	// Children in ascending order of the byte following the node's key.
+	children []*stringRadixNode[V]
+}
+
+// Bounds of a walk over a string radix tree. A nil bound is unbounded.
+type stringRadixRange struct {
+	lo, hi                   *string
+	loInclusive, hiInclusive bool
+}
+
+// NewStringRadixTree creates an empty radix tree with string keys. Like the
+// radix trie, it compresses paths without branches into a single node.
+// Operations are O(m), where m is the length of the key, and a prefix walk
+// visits only the subtree of keys with that prefix.
+func NewStringRadixTree() StringRadixTree {
+	return NewStringRadixTreeOf[interface{}]()
+}
+
+// NewStringRadixTreeOf creates an empty radix tree with string keys and values
+// of type V. See NewStringRadixTree.
+func NewStringRadixTreeOf[V any]() StringRadixTreeOf[V] {
+	return new(stringRadixTree[V])
+}
+
+func (srt *stringRadixTree[V]) Get(key string) (V, bool) {
+	node := srt.find(key)
+
+	if node == nil || node.key != key || !node.leaf {
+		var value V
+		return value, false
+	} else {
+		return node.value, true
+	}
+}
+
+// find returns the node with the shortest key which begins with the given
+// prefix, or nil if no node does.
+func (srt *stringRadixTree[V]) find(prefix string) *stringRadixNode[V] {
+	node := &srt.root
+
+	for len(node.key) < len(prefix) {
+		_, child := node.child(prefix[len(node.key)])
+		if child == nil {
+			return nil
+		}
+
+		// The child's key must match the prefix up to the end of one of them.
+		n := min(len(child.key), len(prefix))
+		if child.key[:n] != prefix[:n] {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+func (srt *stringRadixTree[V]) Set(key string, value V) (V, bool) {
+	node := &srt.root
+
+	for {
+		depth := len(node.key)
+		if depth == len(key) {
+			if node.leaf {
+				origValue := node.value
+				node.value = value
+				return origValue, true
+			}
+			node.leaf = true
+			node.value = value
+			srt.size++
+			var origValue V
+			return origValue, false
+		}
+
+		i, child := node.child(key[depth])
+		if child == nil {
+			node.children = slices.Insert(node.children, i, &stringRadixNode[V]{key: key, value: value, leaf: true})
+			srt.size++
+			var origValue V
+			return origValue, false
+		}
+
+		common := depth + 1
+		for common < len(key) && common < len(child.key) && key[common] == child.key[common] {
+			common++
+		}
+		if common == len(child.key) {
+			node = child
+			continue
+		}
+
+		// The key diverges from the child's key partway along the edge, so
+		// split the edge.
+		mid := &stringRadixNode[V]{key: key[:common], children: []*stringRadixNode[V]{child}}
+		node.children[i] = mid
+		if common == len(key) {
+			mid.leaf = true
+			mid.value = value
+		} else {
+			j, _ := mid.child(key[common])
+			mid.children = slices.Insert(mid.children, j, &stringRadixNode[V]{key: key, value: value, leaf: true})
+		}
+		srt.size++
+		var origValue V
+		return origValue, false
+	}
+}
+
+func (srt *stringRadixTree[V]) Del(key string) (V, bool) {
+	var parent *stringRadixNode[V]
+	node := &srt.root
+
+	for len(node.key) < len(key) {
+		_, child := node.child(key[len(node.key)])
+		if child == nil || !strings.HasPrefix(key, child.key) {
+			var value V
+			return value, false
+		}
+		parent = node
+		node = child
+	}
+
+	if !node.leaf {
+		var value V
+		return value, false
+	}
+	origValue := node.value
+	var value V
+	node.value = value
+	node.leaf = false
+	srt.size--
+
+	// Remove the node if it is now empty, and merge whichever node is left
+	// with a single child into that child.
+	if parent != nil && len(node.children) == 0 {
+		i, _ := parent.child(node.key[len(parent.key)])
+		parent.children = slices.Delete(parent.children, i, i+1)
+		node = parent
+	}
+	if node != &srt.root && !node.leaf && len(node.children) == 1 {
+		*node = *node.children[0]
+	}
+	return origValue, true
+}
+
+func (srt *stringRadixTree[V]) Ascend() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		srt.root.walk(&stringRadixRange{}, false, yield)
+	}
+}
+
+func (srt *stringRadixTree[V]) Descend() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		srt.root.walk(&stringRadixRange{}, true, yield)
+	}
+}
+
+func (srt *stringRadixTree[V]) Range(lo, hi string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		srt.root.walk(&stringRadixRange{lo: &lo, hi: &hi, loInclusive: true}, false, yield)
+	}
+}
+
+func (srt *stringRadixTree[V]) Min() (string, V, bool) {
+	return srt.first(&stringRadixRange{}, false)
+}
+
+func (srt *stringRadixTree[V]) Max() (string, V, bool) {
+	return srt.first(&stringRadixRange{}, true)
+}
+
+func (srt *stringRadixTree[V]) Floor(key string) (string, V, bool) {
+	return srt.first(&stringRadixRange{hi: &key, hiInclusive: true}, true)
+}
+
+func (srt *stringRadixTree[V]) Ceiling(key string) (string, V, bool) {
+	return srt.first(&stringRadixRange{lo: &key, loInclusive: true}, false)
+}
+
+func (srt *stringRadixTree[V]) Predecessor(key string) (string, V, bool) {
+	return srt.first(&stringRadixRange{hi: &key}, true)
+}
+
+func (srt *stringRadixTree[V]) Successor(key string) (string, V, bool) {
+	return srt.first(&stringRadixRange{lo: &key}, false)
+}
+
+func (srt *stringRadixTree[V]) Len() int {
+	return srt.size
+}
+
+func (srt *stringRadixTree[V]) WalkPrefix(prefix string, fn func(string, V) bool) {
+	if node := srt.find(prefix); node != nil {
+		node.walk(&stringRadixRange{}, false, fn)
+	}
+}
+
+func (srt *stringRadixTree[V]) CountPrefix(prefix string) int {
+	count := 0
+	srt.WalkPrefix(prefix, func(string, V) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func (srt *stringRadixTree[V]) LongestPrefix(key string) (string, V, bool) {
+	var best *stringRadixNode[V]
+	node := &srt.root
+
+	for {
+		if node.leaf {
+			best = node
+		}
+		if len(node.key) == len(key) {
+			break
+		}
+		_, child := node.child(key[len(node.key)])
+		if child == nil || !strings.HasPrefix(key, child.key) {
+			break
+		}
+		node = child
+	}
+
+	if best == nil {
+		var value V
+		return "", value, false
+	}
+	return best.key, best.value, true
+}
+
+// first returns the first key and its value visited by a walk.
+func (srt *stringRadixTree[V]) first(r *stringRadixRange, reverse bool) (string, V, bool) {
+	var key string
+	var value V
+	found := false
+
+	srt.root.walk(r, reverse, func(k string, v V) bool {
+		key, value, found = k, v, true
+		return false
+	})
+	return key, value, found
+}
+
+// walk calls yield on every key underneath the node within the given range, in
+// ascending order of keys, or descending order if reverse is true. Subtrees
+// outside of the range are skipped. It returns false if yield returned false.
+func (node *stringRadixNode[V]) walk(r *stringRadixRange, reverse bool, yield func(string, V) bool) bool {
+	// Every key underneath the node begins with the node's key, so they are
+	// all below lo if the node's key is below lo and not a prefix of it, and
+	// they are all above hi if the node's key is.
+	if r.lo != nil && node.key < *r.lo && !strings.HasPrefix(*r.lo, node.key) {
+		return true
+	}
+	if !r.belowHi(node.key) {
+		return true
+	}
+
+	if !reverse && node.leaf && r.aboveLo(node.key) && !yield(node.key, node.value) {
+		return false
+	}
+	for i := range node.children {
+		if reverse {
+			i = len(node.children) - 1 - i
+		}
+		if !node.children[i].walk(r, reverse, yield) {
+			return false
+		}
+	}
+	if reverse && node.leaf && r.aboveLo(node.key) && !yield(node.key, node.value) {
+		return false
+	}
+	return true
+}
+
+// aboveLo returns whether the key is within the lower bound of the range.
+func (r *stringRadixRange) aboveLo(key string) bool {
+	return r.lo == nil || key > *r.lo || (r.loInclusive && key == *r.lo)
+}
+
+// belowHi returns whether the key is within the upper bound of the range.
+func (r *stringRadixRange) belowHi(key string) bool {
+	return r.hi == nil || key < *r.hi || (r.hiInclusive && key == *r.hi)
+}
+
+// child returns the child whose key continues with the given byte and its
+// index. If there is no such child, it returns nil and the index at which it
+// would be inserted.
+func (node *stringRadixNode[V]) child(b byte) (int, *stringRadixNode[V]) {
+	depth := len(node.key)
+	i := sort.Search(len(node.children), func(i int) bool {
+		return node.children[i].key[depth] >= b
+	})
+	if i < len(node.children) && node.children[i].key[depth] == b {
+		return i, node.children[i]
+	}
+	return i, nil
+}
@@ -0,0 +1,173 @@
+package tree
+
+import (
+	"encoding/binary"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newStringTreeAdapter wraps a string radix tree for the generated tests.
+// Keys are encoded in big-endian order, which sorts them the same way.
+func newStringTreeAdapter() Tree {
+	return &keyAdapter[string]{
+		tree: NewStringRadixTree(),
+		encode: func(k uint64) string {
+			return string(binary.BigEndian.AppendUint64(nil, k))
+		},
+		decode: func(k string) uint64 {
+			return binary.BigEndian.Uint64([]byte(k))
+		},
+	}
+}
+
+// checkStringRadix checks that every node below the root of a string radix
+// tree holds a key or has at least two children, and that the children are
+// sorted and extend their parent's key.
+func checkStringRadix(t *testing.T, node *stringRadixNode[int], root bool) {
+	if !root && !node.leaf && len(node.children) < 2 {
+		t.Fatalf("uncompressed node %q with %v children\n", node.key, len(node.children))
+	}
+	for i, child := range node.children {
+		if len(child.key) <= len(node.key) || !strings.HasPrefix(child.key, node.key) {
+			t.Fatalf("child %q does not extend %q\n", child.key, node.key)
+		}
+		if i > 0 && node.children[i-1].key[len(node.key)] >= child.key[len(node.key)] {
+			t.Fatalf("children of %q are not sorted\n", node.key)
+		}
+		checkStringRadix(t, child, false)
+	}
+}
+
+// randomStringKey returns a short random key over a small alphabet so that
+// keys share prefixes and are often prefixes of each other.
+func randomStringKey() string {
+	b := make([]byte, testRand.Intn(8))
+	for i := range b {
+		b[i] = "abc/"[testRand.Intn(4)]
+	}
+	return string(b)
+}
+
+func TestStringRadixTreeRandom(t *testing.T) {
+	srt := NewStringRadixTreeOf[int]()
+	m := map[string]int{}
+
+	for i := 0; i < NUM_NODES; i++ {
+		k := randomStringKey()
+		if testRand.Intn(3) == 0 {
+			v, ok := srt.Del(k)
+			w, exists := m[k]
+			if ok != exists || v != w {
+				t.Fatalf("delete failed: got %v, %v for %q, expected %v, %v\n", v, ok, k, w, exists)
+			}
+			delete(m, k)
+		} else {
+			v, ok := srt.Set(k, i)
+			w, exists := m[k]
+			if ok != exists || v != w {
+				t.Fatalf("set failed: got %v, %v for %q, expected %v, %v\n", v, ok, k, w, exists)
+			}
+			m[k] = i
+		}
+	}
+	checkStringRadix(t, &srt.(*stringRadixTree[int]).root, true)
+
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	if n := srt.Len(); n != len(keys) {
+		t.Errorf("len failed: got %v, expected %v\n", n, len(keys))
+	}
+	i := 0
+	for k, v := range srt.Ascend() {
+		if i >= len(keys) || k != keys[i] || v != m[k] {
+			t.Fatalf("ascend failed: got %q, %v at %v\n", k, v, i)
+		}
+		i++
+	}
+	for k := range srt.Descend() {
+		i--
+		if k != keys[i] {
+			t.Fatalf("descend failed: got %q, expected %q\n", k, keys[i])
+		}
+	}
+
+	for j := 0; j < 100; j++ {
+		k := randomStringKey()
+
+		// The keys with the prefix are contiguous in sorted order.
+		lo, _ := slices.BinarySearch(keys, k)
+		hi := lo
+		for hi < len(keys) && strings.HasPrefix(keys[hi], k) {
+			hi++
+		}
+		var walked []string
+		srt.WalkPrefix(k, func(key string, _ int) bool {
+			walked = append(walked, key)
+			return true
+		})
+		if !slices.Equal(walked, keys[lo:hi]) {
+			t.Fatalf("walk failed: got %q for %q, expected %q\n", walked, k, keys[lo:hi])
+		}
+		if n := srt.CountPrefix(k); n != hi-lo {
+			t.Errorf("count failed: got %v for %q, expected %v\n", n, k, hi-lo)
+		}
+
+		expected, found := "", false
+		for _, key := range keys {
+			if strings.HasPrefix(k, key) && (!found || len(key) > len(expected)) {
+				expected, found = key, true
+			}
+		}
+		if key, _, ok := srt.LongestPrefix(k); ok != found || key != expected {
+			t.Errorf("longest prefix failed: got %q, %v for %q, expected %q, %v\n", key, ok, k, expected, found)
+		}
+
+		if key, _, ok := srt.Floor(k); ok != (lo < len(keys) && keys[lo] == k || lo > 0) ||
+			(ok && key != k && key != keys[lo-1]) {
+			t.Errorf("floor failed: got %q, %v for %q\n", key, ok, k)
+		}
+		if key, _, ok := srt.Successor(k); ok && key <= k {
+			t.Errorf("successor failed: got %q for %q\n", key, k)
+		}
+	}
+
+	for k := range m {
+		srt.Del(k)
+	}
+	root := &srt.(*stringRadixTree[int]).root
+	if srt.Len() != 0 || len(root.children) != 0 {
+		t.Errorf("delete failed: nodes left in empty tree\n")
+	}
+}
+
+func TestStringRadixTreeRoutes(t *testing.T) {
+	srt := NewStringRadixTreeOf[string]()
+	for _, route := range []string{"/", "/api/", "/api/v1/", "/api/v1/users", "/static/"} {
+		srt.Set(route, route)
+	}
+
+	for _, test := range []struct{ path, expected string }{
+		{"/", "/"},
+		{"/index.html", "/"},
+		{"/api", "/"},
+		{"/api/v2/users", "/api/"},
+		{"/api/v1/users/1", "/api/v1/users"},
+		{"/static/app.js", "/static/"},
+	} {
+		if key, v, ok := srt.LongestPrefix(test.path); !ok || key != test.expected || v != test.expected {
+			t.Errorf("longest prefix failed: got %q, %q, %v for %q, expected %q\n",
+				key, v, ok, test.path, test.expected)
+		}
+	}
+	if _, _, ok := srt.LongestPrefix("api"); ok {
+		t.Errorf("longest prefix failed: found prefix of %q\n", "api")
+	}
+	if n := srt.CountPrefix("/api/"); n != 3 {
+		t.Errorf("count failed: got %v for %q, expected 3\n", n, "/api/")
+	}
+}
@@ -0,0 +1,240 @@
+package tree
+
+// Wrap a Tree or Trie for concurrent use.
+
+import (
+	"iter"
+	"sync"
+)
+
+// Tree wrapped with a lock.
+type synchronizedTree[K, V any] struct {
+	tree TreeOf[K, V]
+	lock sync.RWMutex
+
+	// Whether reads modify the tree, in which case they must hold the lock
+	// exclusively.
+	exclusive bool
+}
+
+// Order statistic tree wrapped with a lock.
+type synchronizedOrderStatisticTree[K, V any] struct {
+	*synchronizedTree[K, V]
+}
+
+// Trie wrapped with a lock.
+type synchronizedTrie[V any] struct {
+	*synchronizedTree[uint64, V]
+}
+
+// readMutator is implemented by trees which may modify themselves in methods
+// which only read from them.
+type readMutator interface {
+	// mutatesOnRead returns whether reads modify the tree, in which case
+	// they are not safe to run concurrently.
+	mutatesOnRead() bool
+}
+
+// NewSynchronized wraps a Tree so that it is safe for concurrent use. Reads
+// share a read/write lock unless they modify the underlying tree, as they do
+// in a splay tree, in which case every operation holds it exclusively. The
+// lock is not held while the body of an iteration loop runs, so the body may
+// use the returned tree, but each step of an iteration is a separate lookup.
+// If the given tree is an OrderStatisticTree, so is the returned tree. The
+// given tree must not be used directly afterwards.
+func NewSynchronized(tree Tree) Tree {
+	return NewSynchronizedOf(tree)
+}
+
+// NewSynchronizedOf wraps a TreeOf[K, V] so that it is safe for concurrent
+// use. See NewSynchronized.
+func NewSynchronizedOf[K, V any](tree TreeOf[K, V]) TreeOf[K, V] {
+	st := &synchronizedTree[K, V]{tree: tree}
+	if rm, ok := tree.(readMutator); ok {
+		st.exclusive = rm.mutatesOnRead()
+	}
+	if _, ok := tree.(OrderStatisticTreeOf[K, V]); ok {
+		return synchronizedOrderStatisticTree[K, V]{st}
+	}
+	return st
+}
+
+// NewSynchronizedTrie wraps a Trie so that it is safe for concurrent use. See
+// NewSynchronized.
+func NewSynchronizedTrie(trie Trie) Trie {
+	return NewSynchronizedTrieOf(trie)
+}
+
+// NewSynchronizedTrieOf wraps a TrieOf[V] so that it is safe for concurrent
+// use. See NewSynchronized.
+func NewSynchronizedTrieOf[V any](trie TrieOf[V]) TrieOf[V] {
+	return synchronizedTrie[V]{&synchronizedTree[uint64, V]{tree: trie}}
+}
+
+// rlock acquires the lock for a read.
+func (st *synchronizedTree[K, V]) rlock() {
+	if st.exclusive {
+		st.lock.Lock()
+	} else {
+		st.lock.RLock()
+	}
+}
+
+// runlock releases the lock acquired by rlock.
+func (st *synchronizedTree[K, V]) runlock() {
+	if st.exclusive {
+		st.lock.Unlock()
+	} else {
+		st.lock.RUnlock()
+	}
+}
+
+func (st *synchronizedTree[K, V]) Get(key K) (V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Get(key)
+}
+
+func (st *synchronizedTree[K, V]) Set(key K, value V) (V, bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return st.tree.Set(key, value)
+}
+
+func (st *synchronizedTree[K, V]) Del(key K) (V, bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return st.tree.Del(key)
+}
+
+func (st *synchronizedTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return st.seq(st.tree.Min, st.tree.Successor)
+}
+
+func (st *synchronizedTree[K, V]) Descend() iter.Seq2[K, V] {
+	return st.seq(st.tree.Max, st.tree.Predecessor)
+}
+
+func (st *synchronizedTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	// A key is below hi if the range from it to hi contains it.
+	belowHi := func(key K, value V, ok bool) (K, V, bool) {
+		if ok {
+			for key, value := range st.tree.Range(key, hi) {
+				return key, value, true
+			}
+		}
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, false
+	}
+	return st.seq(func() (K, V, bool) {
+		return belowHi(st.tree.Ceiling(lo))
+	}, func(key K) (K, V, bool) {
+		return belowHi(st.tree.Successor(key))
+	})
+}
+
+// seq returns an iterator which starts at the entry returned by first and
+// moves to each following entry with next. The lock is only held while
+// looking up each entry, not while the loop body runs, so the body may use the
+// tree. Each entry is looked up from the previous key, so changes made by the
+// body or by other goroutines between entries are reflected in the rest of the
+// iteration.
+func (st *synchronizedTree[K, V]) seq(first func() (K, V, bool), next func(K) (K, V, bool)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		key, value, ok := st.step(first)
+		for ok && yield(key, value) {
+			key, value, ok = st.step(func() (K, V, bool) {
+				return next(key)
+			})
+		}
+	}
+}
+
+// step calls the given function with the lock held for a read.
+func (st *synchronizedTree[K, V]) step(f func() (K, V, bool)) (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return f()
+}
+
+func (st *synchronizedTree[K, V]) Min() (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Min()
+}
+
+func (st *synchronizedTree[K, V]) Max() (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Max()
+}
+
+func (st *synchronizedTree[K, V]) Floor(key K) (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Floor(key)
+}
+
+func (st *synchronizedTree[K, V]) Ceiling(key K) (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Ceiling(key)
+}
+
+func (st *synchronizedTree[K, V]) Predecessor(key K) (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Predecessor(key)
+}
+
+func (st *synchronizedTree[K, V]) Successor(key K) (K, V, bool) {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Successor(key)
+}
+
+func (st *synchronizedTree[K, V]) Len() int {
+	st.rlock()
+	defer st.runlock()
+	return st.tree.Len()
+}
+
+func (sost synchronizedOrderStatisticTree[K, V]) Rank(key K) int {
+	sost.rlock()
+	defer sost.runlock()
+	return sost.tree.(OrderStatisticTreeOf[K, V]).Rank(key)
+}
+
+func (sost synchronizedOrderStatisticTree[K, V]) Select(i int) (K, V, bool) {
+	sost.rlock()
+	defer sost.runlock()
+	return sost.tree.(OrderStatisticTreeOf[K, V]).Select(i)
+}
+
+func (strie synchronizedTrie[V]) WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool) {
+	lo, hi, ok := triePrefixRange(prefix, bits)
+	if !ok {
+		return
+	}
+	// Like Range, only hold the lock while looking up each key.
+	upToHi := func(key uint64, value V, ok bool) (uint64, V, bool) {
+		return key, value, ok && key <= hi
+	}
+	seq := strie.seq(func() (uint64, V, bool) {
+		return upToHi(strie.tree.Ceiling(lo))
+	}, func(key uint64) (uint64, V, bool) {
+		return upToHi(strie.tree.Successor(key))
+	})
+	for key, value := range seq {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+func (strie synchronizedTrie[V]) CountPrefix(prefix uint64, bits uint) int {
+	strie.rlock()
+	defer strie.runlock()
+	return strie.tree.(TrieOf[V]).CountPrefix(prefix, bits)
+}
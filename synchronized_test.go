@@ -0,0 +1,189 @@
+package tree
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// These tests are mostly useful with the race detector (go test -race).
+
+const NUM_GOROUTINES = 8
+
+// hammer concurrently modifies and reads a synchronized tree from many
+// goroutines. Each goroutine owns a disjoint set of keys, so the final
+// contents of the tree are known.
+func hammer(t *testing.T, tree Tree) {
+	const keysPerGoroutine = NUM_NODES / NUM_GOROUTINES
+
+	var wg sync.WaitGroup
+	for g := 0; g < NUM_GOROUTINES; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			base := g * keysPerGoroutine
+			for _, i := range rand.Perm(keysPerGoroutine) {
+				k := Uint64Key(base + i)
+				tree.Set(k, base+i)
+				if v, ok := tree.Get(k); !ok || v != base+i {
+					t.Errorf("get failed: got %v, %v for %v\n", v, ok, k)
+					return
+				}
+				if i%2 == 1 {
+					tree.Del(k)
+				}
+
+				switch rand.Intn(8) {
+				case 0:
+					for range tree.Range(Uint64Key(base), Uint64Key(base+i)) {
+					}
+				case 1:
+					for range tree.Descend() {
+						break
+					}
+				case 2:
+					tree.Floor(k)
+					tree.Successor(k)
+				case 3:
+					tree.Min()
+					tree.Max()
+					tree.Len()
+				case 4:
+					if ost, ok := tree.(OrderStatisticTree); ok {
+						ost.Select(ost.Rank(k))
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if n := tree.Len(); n != NUM_GOROUTINES*keysPerGoroutine/2 {
+		t.Errorf("len failed: got %v, expected %v\n", n, NUM_GOROUTINES*keysPerGoroutine/2)
+	}
+	i := 0
+	for k, v := range tree.Ascend() {
+		if k != Uint64Key(2*i) || v != 2*i {
+			t.Fatalf("ascend failed: got %v, %v, expected %v\n", k, v, 2*i)
+		}
+		i++
+	}
+}
+
+func TestSynchronizedBST(t *testing.T) {
+	hammer(t, NewSynchronized(NewBST()))
+}
+
+func TestSynchronizedSplay(t *testing.T) {
+	hammer(t, NewSynchronized(NewSplay()))
+}
+
+func TestSynchronizedAVL(t *testing.T) {
+	hammer(t, NewSynchronized(NewAVL()))
+}
+
+func TestSynchronizedRedBlack(t *testing.T) {
+	hammer(t, NewSynchronized(NewRedBlack()))
+}
+
+func TestSynchronizedTreap(t *testing.T) {
+	hammer(t, NewSynchronized(NewTreap()))
+}
+
+func TestSynchronizedBTree(t *testing.T) {
+	hammer(t, NewSynchronized(NewBTree(4)))
+}
+
+func TestSynchronizedSkipList(t *testing.T) {
+	hammer(t, NewSynchronized(NewSkipList()))
+}
+
+func TestSynchronizedSimpleTrie(t *testing.T) {
+	hammer(t, NewTreeFromTrie(NewSynchronizedTrie(NewBinaryTrie())))
+}
+
+func TestSynchronizedCLZTrie(t *testing.T) {
+	hammer(t, NewTreeFromTrie(NewSynchronizedTrie(NewCLZTrie())))
+}
+
+func TestSynchronizedRadixTrie(t *testing.T) {
+	hammer(t, NewTreeFromTrie(NewSynchronizedTrie(NewRadixTrie())))
+}
+
+func TestSynchronizedOrderStatistic(t *testing.T) {
+	if _, ok := NewSynchronized(NewAVL()).(OrderStatisticTree); !ok {
+		t.Errorf("synchronized AVL tree is not an OrderStatisticTree\n")
+	}
+	if _, ok := NewSynchronized(NewBTree(4)).(OrderStatisticTree); ok {
+		t.Errorf("synchronized B-tree is an OrderStatisticTree\n")
+	}
+}
+
+func TestSynchronizedSplayIterate(t *testing.T) {
+	// Get splays the tree, so it needs the lock exclusively. The loop body
+	// must still be able to call it and to modify the tree.
+	tree := NewSynchronized(NewSplay())
+	for i := 0; i < NUM_NODES; i++ {
+		tree.Set(Uint64Key(i), i)
+	}
+
+	i := 0
+	for k, v := range tree.Ascend() {
+		if v2, ok := tree.Get(k); !ok || v2 != v || k != Uint64Key(i) {
+			t.Fatalf("get failed: got %v, %v, %v, expected %v\n", k, v2, ok, i)
+		}
+		i++
+	}
+	if i != NUM_NODES {
+		t.Errorf("ascend failed: visited %v keys, expected %v\n", i, NUM_NODES)
+	}
+
+	i = 0
+	for k := range tree.Range(Uint64Key(NUM_NODES/4), Uint64Key(NUM_NODES/2)) {
+		if k != Uint64Key(NUM_NODES/4+2*i) {
+			t.Fatalf("range failed: got %v, expected %v\n", k, NUM_NODES/4+2*i)
+		}
+		// Delete the next key before the iteration reaches it.
+		tree.Del(Uint64Key(NUM_NODES/4 + 2*i + 1))
+		i++
+	}
+	if i != NUM_NODES/8 {
+		t.Errorf("range failed: visited %v keys, expected %v\n", i, NUM_NODES/8)
+	}
+
+	for k := range tree.Descend() {
+		tree.Del(k)
+	}
+	if n := tree.Len(); n != 0 {
+		t.Errorf("delete failed: got %v keys, expected 0\n", n)
+	}
+}
+
+func TestSynchronizedTrieWalkPrefix(t *testing.T) {
+	trie := NewSynchronizedTrie(NewRadixTrie())
+	for i := 0; i < NUM_NODES; i++ {
+		trie.Set(uint64(i%4)<<62|uint64(i), i)
+	}
+
+	n := 0
+	trie.WalkPrefix(1, 2, func(k uint64, v interface{}) bool {
+		if k>>62 != 1 {
+			t.Errorf("walk failed: got %x for prefix 1/2\n", k)
+		}
+		trie.Del(k)
+		n++
+		return true
+	})
+	if n != NUM_NODES/4 || trie.CountPrefix(1, 2) != 0 {
+		t.Errorf("walk failed: visited %v keys, expected %v\n", n, NUM_NODES/4)
+	}
+}
+
+func TestSynchronizedExclusive(t *testing.T) {
+	if !NewSynchronized(NewSplay()).(synchronizedOrderStatisticTree[Key, interface{}]).exclusive {
+		t.Errorf("synchronized splay tree shares its lock for reads\n")
+	}
+	if NewSynchronized(NewBST()).(synchronizedOrderStatisticTree[Key, interface{}]).exclusive {
+		t.Errorf("synchronized BST does not share its lock for reads\n")
+	}
+}
@@ -0,0 +1,194 @@
+package tree
+
+// Treap implementation.
+
+import (
+	"cmp"
+	"math/rand"
+)
+
+// Treap dynamic set which can be split and merged with Key keys and
+// interface{} values.
+type Treap = TreapOf[Key, interface{}]
+
+// Treap dynamic set which can be split and merged with keys of type K and
+// values of type V.
+type TreapOf[K, V any] interface {
+	OrderStatisticTreeOf[K, V]
+
+	// Split removes the keys greater than or equal to the given key from the
+	// treap and returns a new treap containing them.
+	Split(K) TreapOf[K, V]
+
+	// Merge moves all of the keys from the given treap into this one, leaving
+	// the given treap empty. Either all of the keys in the given treap must be
+	// less than the keys in this one or all of them must be greater;
+	// otherwise, Merge panics. The given treap must have been created by the
+	// same constructor as this one.
+	Merge(TreapOf[K, V])
+}
+
+// Treap.
+type treap[K, V any] struct {
+	// Underlying binary search tree. The balance of each node is a random
+	// priority, and every node has a priority greater than or equal to the
+	// priorities of its children.
+	binarySearchTree[K, V]
+}
+
+// NewTreap creates an empty treap. A treap is a binary search tree which is
+// also a heap on randomly assigned priorities, which makes its shape that of a
+// random binary search tree. All operations are O(log n) expected, including
+// Split and Merge.
+func NewTreap() Treap {
+	return NewTreapOfFunc[Key, interface{}](compareKeys)
+}
+
+// NewTreapOf creates an empty treap ordered by the natural order of its keys.
+// See NewTreap.
+func NewTreapOf[K cmp.Ordered, V any]() TreapOf[K, V] {
+	return NewTreapOfFunc[K, V](cmp.Compare[K])
+}
+
+// NewTreapOfFunc creates an empty treap ordered by the given comparison
+// function. See NewTreap and NewBSTOfFunc.
+func NewTreapOfFunc[K, V any](compare func(K, K) int) TreapOf[K, V] {
+	t := new(treap[K, V])
+	t.compare = compare
+	return t
+}
+
+// NewTreapFunc creates an empty treap ordered by the given comparison function.
+// See NewTreap and NewBSTFunc.
+func NewTreapFunc(compare func(a, b interface{}) int) TreapOf[interface{}, interface{}] {
+	return NewTreapOfFunc[interface{}, interface{}](compare)
+}
+
+func (t *treap[K, V]) Set(key K, value V) (V, bool) {
+	node, exists := t.add(key)
+
+	if exists {
+		origValue := node.value
+		node.value = value
+		return origValue, true
+	}
+
+	node.value = value
+	node.balance = rand.Int()
+
+	// Rotate the new node up until the heap property is restored.
+	for node.parent != nil && node.balance > node.parent.balance {
+		if node == node.parent.left {
+			t.rotateRight(node.parent)
+		} else {
+			t.rotateLeft(node.parent)
+		}
+	}
+
+	var origValue V
+	return origValue, false
+}
+
+func (t *treap[K, V]) Del(key K) (V, bool) {
+	node := t.get(key)
+
+	if node == nil {
+		var value V
+		return value, false
+	} else {
+		t.replace(node, treapMerge(node.right, node.left))
+		node.parent.resizeUp()
+		return node.value, true
+	}
+}
+
+func (t *treap[K, V]) Split(key K) TreapOf[K, V] {
+	lo, hi := t.split(t.root, key)
+	t.setRoot(lo)
+
+	upper := &treap[K, V]{binarySearchTree[K, V]{compare: t.compare}}
+	upper.setRoot(hi)
+	return upper
+}
+
+func (t *treap[K, V]) Merge(other TreapOf[K, V]) {
+	o := other.(*treap[K, V])
+
+	switch {
+	case o.root == nil:
+		return
+	case t.root == nil:
+		t.setRoot(o.root)
+	case t.compare(t.max().key, o.min().key) < 0:
+		t.setRoot(treapMerge(t.root, o.root))
+	case t.compare(o.max().key, t.min().key) < 0:
+		t.setRoot(treapMerge(o.root, t.root))
+	default:
+		panic("overlapping treaps")
+	}
+	o.root = nil
+}
+
+// setRoot makes the given node, which may be nil, the root of the treap.
+func (t *treap[K, V]) setRoot(node *bstNode[K, V]) {
+	t.root = node
+	if node != nil {
+		node.parent = nil
+	}
+}
+
+// split splits the subtree rooted at the given node into a subtree with the
+// keys less than the given key and a subtree with the keys greater than or
+// equal to it and returns their roots. The parents of the returned roots are
+// not updated.
+func (t *treap[K, V]) split(node *bstNode[K, V], key K) (*bstNode[K, V], *bstNode[K, V]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if t.compare(node.key, key) < 0 {
+		// The node and its right subtree are less than the key, but its left
+		// subtree may not be.
+		lo, hi := t.split(node.left, key)
+		node.left = lo
+		if lo != nil {
+			lo.parent = node
+		}
+		node.resize()
+		return node, hi
+	} else {
+		// The node and its left subtree are greater than or equal to the key,
+		// but its right subtree may not be.
+		lo, hi := t.split(node.right, key)
+		node.right = hi
+		if hi != nil {
+			hi.parent = node
+		}
+		node.resize()
+		return lo, node
+	}
+}
+
+// treapMerge merges two subtrees where all of the keys in lo are less than all
+// of the keys in hi and returns the root of the result. The parent of the
+// returned root is not updated.
+func treapMerge[K, V any](lo, hi *bstNode[K, V]) *bstNode[K, V] {
+	if lo == nil {
+		return hi
+	} else if hi == nil {
+		return lo
+	}
+
+	if lo.balance > hi.balance {
+		// Greater keys go on the left.
+		lo.left = treapMerge(lo.left, hi)
+		lo.left.parent = lo
+		lo.resize()
+		return lo
+	} else {
+		hi.right = treapMerge(lo, hi.right)
+		hi.right.parent = hi
+		hi.resize()
+		return hi
+	}
+}
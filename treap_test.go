@@ -0,0 +1,87 @@
+package tree
+
+import (
+	"testing"
+)
+
+// checkTreap checks the heap property, parent pointers, and subtree sizes of
+// a treap.
+func checkTreap(t *testing.T, node *bstNode[Key, interface{}]) {
+	if node == nil {
+		return
+	}
+
+	for _, child := range []*bstNode[Key, interface{}]{node.left, node.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != node {
+			t.Fatalf("incorrect parent for %v\n", child.key)
+		}
+		if child.balance > node.balance {
+			t.Fatalf("heap property violated between %v and %v\n",
+				node.key, child.key)
+		}
+		checkTreap(t, child)
+	}
+	if size := 1 + node.left.subtreeSize() + node.right.subtreeSize(); node.size != size {
+		t.Fatalf("incorrect size for %v: got %v, expected %v\n",
+			node.key, node.size, size)
+	}
+}
+
+// checkTreapKeys checks that a treap contains exactly the keys from lo to hi.
+func checkTreapKeys(t *testing.T, tr Treap, lo, hi int) {
+	checkTreap(t, tr.(*treap[Key, interface{}]).root)
+	if n := tr.Len(); n != hi-lo {
+		t.Errorf("treap has %v keys, expected %v\n", n, hi-lo)
+	}
+	i := lo
+	for k, v := range tr.Ascend() {
+		if k != Uint64Key(i) || v != i {
+			t.Fatalf("got %v, %v, expected %v\n", k, v, i)
+		}
+		i++
+	}
+}
+
+func TestTreapSplitMerge(t *testing.T) {
+	tr := NewTreap()
+	for _, v := range testRand.Perm(NUM_NODES) {
+		tr.Set(Uint64Key(v), v)
+	}
+	checkTreapKeys(t, tr, 0, NUM_NODES)
+
+	for i := 0; i < 100; i++ {
+		key := testRand.Intn(NUM_NODES + 1)
+		upper := tr.Split(Uint64Key(key))
+		checkTreapKeys(t, tr, 0, key)
+		checkTreapKeys(t, upper, key, NUM_NODES)
+
+		// Merge in either direction.
+		if i%2 == 0 {
+			tr.Merge(upper)
+		} else {
+			upper.Merge(tr)
+			tr, upper = upper, tr
+		}
+		checkTreapKeys(t, tr, 0, NUM_NODES)
+		checkTreapKeys(t, upper, 0, 0)
+	}
+}
+
+func TestTreapMergeOverlapping(t *testing.T) {
+	tr1 := NewTreap()
+	tr2 := NewTreap()
+	for i := 0; i < 10; i++ {
+		tr1.Set(Uint64Key(2*i), i)
+		tr2.Set(Uint64Key(2*i+1), i)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("merging overlapping treaps did not panic\n")
+		}
+	}()
+	tr1.Merge(tr2)
+}
@@ -1,27 +1,101 @@
 /*
 Package tree implements several tree structures.
+
+Every structure is available both through the generic TreeOf and TrieOf
+interfaces and through the Tree and Trie interfaces, which store interface{}
+values under Keys and uint64s, respectively.
 */
 package tree
 
+import (
+	"iter"
+)
+
 // Key in a tree.
 type Key interface {
 	CompareTo(Key) int
 }
 
-// Tree dynamic set.
-type Tree interface {
+// Tree dynamic set with Key keys and interface{} values.
+type Tree = TreeOf[Key, interface{}]
+
+// Tree which supports order statistics with Key keys and interface{} values.
+type OrderStatisticTree = OrderStatisticTreeOf[Key, interface{}]
+
+// compareKeys orders Keys for the generic implementations.
+func compareKeys(a, b Key) int {
+	return a.CompareTo(b)
+}
+
+// Tree dynamic set with keys of type K and values of type V.
+type TreeOf[K, V any] interface {
 	// Get returns the value corresponding to the given key. If the key was in
 	// the tree, it returns the corresponding value and true; otherwise, it
 	// returns false.
-	Get(Key) (interface{}, bool)
+	Get(K) (V, bool)
 
 	// Set inserts a value with the given key to a tree. If the key was already
 	// in the tree, it returns the old value and true; otherwise, it returns
 	// false.
-	Set(Key, interface{}) (interface{}, bool)
+	Set(K, V) (V, bool)
 
 	// Del removes the node with the given key from the tree. If the key was in
 	// the tree, it returns the corresponding value and true; otherwise, it
 	// returns false.
-	Del(Key) (interface{}, bool)
+	Del(K) (V, bool)
+
+	// Ascend returns an iterator over the keys and values in the tree in
+	// ascending order of keys. The tree must not be modified during iteration.
+	Ascend() iter.Seq2[K, V]
+
+	// Descend returns an iterator over the keys and values in the tree in
+	// descending order of keys. The tree must not be modified during
+	// iteration.
+	Descend() iter.Seq2[K, V]
+
+	// Range returns an iterator over the keys and values in the tree with
+	// keys greater than or equal to lo and less than hi, in ascending order of
+	// keys. The tree must not be modified during iteration.
+	Range(lo, hi K) iter.Seq2[K, V]
+
+	// Min returns the smallest key in the tree and its value. If the tree is
+	// empty, it returns false.
+	Min() (K, V, bool)
+
+	// Max returns the largest key in the tree and its value. If the tree is
+	// empty, it returns false.
+	Max() (K, V, bool)
+
+	// Floor returns the largest key in the tree less than or equal to the
+	// given key and its value. If there is no such key, it returns false.
+	Floor(K) (K, V, bool)
+
+	// Ceiling returns the smallest key in the tree greater than or equal to
+	// the given key and its value. If there is no such key, it returns false.
+	Ceiling(K) (K, V, bool)
+
+	// Predecessor returns the largest key in the tree less than the given key
+	// and its value. If there is no such key, it returns false.
+	Predecessor(K) (K, V, bool)
+
+	// Successor returns the smallest key in the tree greater than the given
+	// key and its value. If there is no such key, it returns false.
+	Successor(K) (K, V, bool)
+
+	// Len returns the number of keys in the tree.
+	Len() int
+}
+
+// Tree which supports order statistics with keys of type K and values of type
+// V.
+type OrderStatisticTreeOf[K, V any] interface {
+	TreeOf[K, V]
+
+	// Rank returns the number of keys in the tree less than the given key.
+	Rank(K) int
+
+	// Select returns the key with the given rank (i.e., the i-th smallest key,
+	// counting from zero) and its value. If the rank is out of range, it
+	// returns false.
+	Select(int) (K, V, bool)
 }
@@ -1,19 +1,194 @@
 package tree
 
-// Bitwise trie dynamic set.
-type Trie interface {
-	// Get returns the value corresponding to the given key. If the key was in
-	// the tree, it returns the corresponding value and true; otherwise, it
-	// returns false.
-	Get(uint64) (interface{}, bool)
-
-	// Set inserts a value with the given key to a tree. If the key was already
-	// in the tree, it returns the old value and true; otherwise, it returns
-	// false.
-	Set(uint64, interface{}) (interface{}, bool)
-
-	// Del removes the node with the given key from the tree. If the key was in
-	// the tree, it returns the corresponding value and true; otherwise, it
-	// returns false.
-	Del(uint64) (interface{}, bool)
+import (
+	"math"
+)
+
+// Bitwise trie dynamic set with interface{} values.
+type Trie = TrieOf[interface{}]
+
+// Bitwise trie dynamic set with values of type V. A trie is ordered by its
+// uint64 keys, so it is also a TreeOf[uint64, V].
+type TrieOf[V any] interface {
+	TreeOf[uint64, V]
+
+	// WalkPrefix calls fn on every key in the trie whose top bits bits are
+	// equal to prefix, in ascending order of keys, until fn returns false.
+	// For example, WalkPrefix(5, 8, fn) visits the keys from 0x05000000...
+	// through 0x05ffffff.... If bits is 0, every key is visited. The trie
+	// must not be modified during the walk.
+	WalkPrefix(prefix uint64, bits uint, fn func(uint64, V) bool)
+
+	// CountPrefix returns the number of keys in the trie whose top bits bits
+	// are equal to prefix. See WalkPrefix.
+	CountPrefix(prefix uint64, bits uint) int
+}
+
+// trieKey is the set of key types of the bitwise tries.
+type trieKey interface {
+	uint32 | uint64 | Uint128
+}
+
+// trieKeyWidth returns the number of bits in a key of type K.
+func trieKeyWidth[K trieKey]() uint {
+	var key K
+	switch any(key).(type) {
+	case uint32:
+		return 32
+	case uint64:
+		return 64
+	default:
+		return 128
+	}
+}
+
+// trieKeyToUint128 zero-extends a key to 128 bits.
+func trieKeyToUint128[K trieKey](key K) Uint128 {
+	switch key := any(key).(type) {
+	case uint32:
+		return Uint128{0, uint64(key)}
+	case uint64:
+		return Uint128{0, key}
+	default:
+		return key.(Uint128)
+	}
+}
+
+// trieKeyFromUint128 truncates a 128-bit integer to a key of type K.
+func trieKeyFromUint128[K trieKey](u Uint128) K {
+	var key K
+	switch key := any(&key).(type) {
+	case *uint32:
+		*key = uint32(u.Lo)
+	case *uint64:
+		*key = u.Lo
+	case *Uint128:
+		*key = u
+	}
+	return key
+}
+
+// trieKeyMax returns the largest key of type K.
+func trieKeyMax[K trieKey]() K {
+	var max K
+	switch max := any(&max).(type) {
+	case *uint32:
+		*max = math.MaxUint32
+	case *uint64:
+		*max = math.MaxUint64
+	case *Uint128:
+		*max = maxUint128
+	}
+	return max
+}
+
+// trieWalkFunc calls yield on every key in a trie between lo and hi,
+// inclusive, in ascending order of keys, or descending order if reverse is
+// true. It returns false if yield returned false.
+type trieWalkFunc[K trieKey, V any] func(lo, hi K, reverse bool, yield func(K, V) bool) bool
+
+// Each of the lookups below walks with its own closure rather than through a
+// shared helper so that it is small enough to be inlined into the trie's
+// method, which keeps the closure off of the heap.
+
+func (walk trieWalkFunc[K, V]) min() (key K, value V, found bool) {
+	var zero K
+	walk(zero, trieKeyMax[K](), false, func(k K, v V) bool {
+		key, value, found = k, v, true
+		return false
+	})
+	return
+}
+
+func (walk trieWalkFunc[K, V]) max() (key K, value V, found bool) {
+	var zero K
+	walk(zero, trieKeyMax[K](), true, func(k K, v V) bool {
+		key, value, found = k, v, true
+		return false
+	})
+	return
+}
+
+func (walk trieWalkFunc[K, V]) floor(key K) (k K, v V, found bool) {
+	var zero K
+	walk(zero, key, true, func(kk K, vv V) bool {
+		k, v, found = kk, vv, true
+		return false
+	})
+	return
+}
+
+func (walk trieWalkFunc[K, V]) ceiling(key K) (k K, v V, found bool) {
+	walk(key, trieKeyMax[K](), false, func(kk K, vv V) bool {
+		k, v, found = kk, vv, true
+		return false
+	})
+	return
+}
+
+// predecessor is like floor, but passes over the key itself.
+func (walk trieWalkFunc[K, V]) predecessor(key K) (k K, v V, found bool) {
+	var zero K
+	walk(zero, key, true, func(kk K, vv V) bool {
+		if kk == key {
+			return true
+		}
+		k, v, found = kk, vv, true
+		return false
+	})
+	return
+}
+
+// successor is like ceiling, but passes over the key itself.
+func (walk trieWalkFunc[K, V]) successor(key K) (k K, v V, found bool) {
+	walk(key, trieKeyMax[K](), false, func(kk K, vv V) bool {
+		if kk == key {
+			return true
+		}
+		k, v, found = kk, vv, true
+		return false
+	})
+	return
+}
+
+// rangeSeq calls yield on every key greater than or equal to lo and less than
+// hi.
+func (walk trieWalkFunc[K, V]) rangeSeq(lo, hi K, yield func(K, V) bool) {
+	if trieKeyToUint128(lo).Compare(trieKeyToUint128(hi)) < 0 {
+		walk(lo, trieKeyFromUint128[K](trieKeyToUint128(hi).sub(1)), false, yield)
+	}
+}
+
+// prefix calls fn on every key with the given prefix. See TrieOf.WalkPrefix.
+func (walk trieWalkFunc[K, V]) prefix(prefix K, bits uint, fn func(K, V) bool) {
+	if lo, hi, ok := triePrefixRange(prefix, bits); ok {
+		walk(lo, hi, false, fn)
+	}
+}
+
+// countPrefix counts the keys with the given prefix.
+func (walk trieWalkFunc[K, V]) countPrefix(prefix K, bits uint) int {
+	count := 0
+	walk.prefix(prefix, bits, func(K, V) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// triePrefixRange returns the smallest and largest keys with the given prefix
+// of the given number of bits. It returns false if no key has the prefix.
+func triePrefixRange[K trieKey](prefix K, bits uint) (K, K, bool) {
+	width := trieKeyWidth[K]()
+	if bits > width {
+		panic("invalid prefix length")
+	}
+	p := trieKeyToUint128(prefix)
+	if p.rsh(bits) != (Uint128{}) {
+		var zero K
+		return zero, zero, false
+	}
+	lo := p.lsh(width - bits)
+	hi := lo.or(maxUint128.rsh(128 - width + bits))
+	return trieKeyFromUint128[K](lo), trieKeyFromUint128[K](hi), true
 }
@@ -0,0 +1,113 @@
+package tree
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// Uint128 is an unsigned 128-bit integer, such as a UUID or an IPv6 address,
+// given as its high and low halves.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// Uint128From16 returns the Uint128 represented by the given big-endian bytes.
+func Uint128From16(b [16]byte) Uint128 {
+	return Uint128{binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])}
+}
+
+// As16 returns the big-endian bytes representing the integer.
+func (u Uint128) As16() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], u.Hi)
+	binary.BigEndian.PutUint64(b[8:], u.Lo)
+	return b
+}
+
+// Compare returns -1, 0, or 1 if u is less than, equal to, or greater than v,
+// respectively.
+func (u Uint128) Compare(v Uint128) int {
+	if u.Hi < v.Hi || (u.Hi == v.Hi && u.Lo < v.Lo) {
+		return -1
+	} else if u == v {
+		return 0
+	} else {
+		return 1
+	}
+}
+
+// Largest Uint128.
+var maxUint128 = Uint128{math.MaxUint64, math.MaxUint64}
+
+// add returns u + n, wrapping around on overflow.
+func (u Uint128) add(n uint64) Uint128 {
+	lo, carry := bits.Add64(u.Lo, n, 0)
+	return Uint128{u.Hi + carry, lo}
+}
+
+// sub returns u - n, wrapping around on underflow.
+func (u Uint128) sub(n uint64) Uint128 {
+	lo, borrow := bits.Sub64(u.Lo, n, 0)
+	return Uint128{u.Hi - borrow, lo}
+}
+
+func (u Uint128) or(v Uint128) Uint128 {
+	return Uint128{u.Hi | v.Hi, u.Lo | v.Lo}
+}
+
+func (u Uint128) xor(v Uint128) Uint128 {
+	return Uint128{u.Hi ^ v.Hi, u.Lo ^ v.Lo}
+}
+
+// lsh returns u shifted left by n bits. Shifts of 128 bits or more return 0.
+func (u Uint128) lsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{u.Lo << (n - 64), 0}
+	}
+	return Uint128{u.Hi<<n | u.Lo>>(64-n), u.Lo << n}
+}
+
+// rsh returns u shifted right by n bits. Shifts of 128 bits or more return 0.
+func (u Uint128) rsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{0, u.Hi >> (n - 64)}
+	}
+	return Uint128{u.Hi >> n, u.Lo>>n | u.Hi<<(64-n)}
+}
+
+// bit returns the given bit of the integer, counting from the most significant
+// bit.
+func (u Uint128) bit(i uint) uint64 {
+	if i < 64 {
+		return (u.Hi >> (63 - i)) & 1
+	} else {
+		return (u.Lo >> (127 - i)) & 1
+	}
+}
+
+// leadingZeros returns the number of leading zero bits in the integer.
+func (u Uint128) leadingZeros() uint {
+	if u.Hi != 0 {
+		return uint(bits.LeadingZeros64(u.Hi))
+	}
+	return 64 + uint(bits.LeadingZeros64(u.Lo))
+}
+
+// Bitwise trie dynamic set with Uint128 keys and interface{} values.
+type Trie128 = Trie128Of[interface{}]
+
+// Bitwise trie dynamic set with Uint128 keys and values of type V. It is the
+// 128-bit counterpart of TrieOf.
+type Trie128Of[V any] interface {
+	TreeOf[Uint128, V]
+
+	// WalkPrefix calls fn on every key in the trie whose top bits bits are
+	// equal to prefix, in ascending order of keys, until fn returns false.
+	// See TrieOf.WalkPrefix.
+	WalkPrefix(prefix Uint128, bits uint, fn func(Uint128, V) bool)
+
+	// CountPrefix returns the number of keys in the trie whose top bits bits
+	// are equal to prefix. See WalkPrefix.
+	CountPrefix(prefix Uint128, bits uint) int
+}
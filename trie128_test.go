@@ -0,0 +1,142 @@
+package tree
+
+import (
+	"testing"
+)
+
+// newTrie128Adapter wraps a 128-bit trie for the generated tests through
+// NewTreeFromTrie128. Each key is split across both halves of a Uint128 in an
+// order-preserving way so that the tests cross the boundary between them.
+func newTrie128Adapter(trie Trie128) Tree {
+	return &keyAdapter[Key]{
+		tree: NewTreeFromTrie128(trie),
+		encode: func(k uint64) Key {
+			return Uint128Key{k >> 32, k << 32}
+		},
+		decode: func(k Key) uint64 {
+			u := k.(Uint128Key)
+			return u.Hi<<32 | u.Lo>>32
+		},
+	}
+}
+
+func TestUint128(t *testing.T) {
+	u := Uint128{0x0123456789abcdef, 0xfedcba9876543210}
+	b := u.As16()
+	if b[0] != 0x01 || b[15] != 0x10 || Uint128From16(b) != u {
+		t.Errorf("bytes failed: got %x for %x\n", b, u)
+	}
+
+	for _, test := range []struct {
+		got, expected Uint128
+	}{
+		{u.lsh(0), u},
+		{u.lsh(4), Uint128{0x123456789abcdeff, 0xedcba98765432100}},
+		{u.lsh(64), Uint128{0xfedcba9876543210, 0}},
+		{u.lsh(68), Uint128{0xedcba98765432100, 0}},
+		{u.lsh(128), Uint128{}},
+		{u.rsh(0), u},
+		{u.rsh(4), Uint128{0x00123456789abcde, 0xffedcba987654321}},
+		{u.rsh(64), Uint128{0, 0x0123456789abcdef}},
+		{u.rsh(128), Uint128{}},
+		{Uint128{0, maxUint128.Lo}.add(1), Uint128{1, 0}},
+		{maxUint128.add(1), Uint128{}},
+		{Uint128{1, 0}.sub(1), Uint128{0, maxUint128.Lo}},
+	} {
+		if test.got != test.expected {
+			t.Errorf("got %x, expected %x\n", test.got, test.expected)
+		}
+	}
+
+	if u.Compare(Uint128{u.Hi, u.Lo + 1}) != -1 || u.Compare(Uint128{u.Hi - 1, maxUint128.Lo}) != 1 ||
+		u.Compare(u) != 0 {
+		t.Errorf("compare failed\n")
+	}
+	if u.bit(0) != 0 || u.bit(7) != 1 || u.bit(64) != 1 || u.bit(127) != 0 {
+		t.Errorf("bit failed\n")
+	}
+	if n := (Uint128{0, 1}).leadingZeros(); n != 127 {
+		t.Errorf("leading zeros failed: got %v, expected 127\n", n)
+	}
+}
+
+// testTrie128Edges checks the keys at the ends of the key space and on either
+// side of the boundary between the halves, which the generated tests do not
+// reach.
+func testTrie128Edges(t *testing.T, trie Trie128Of[int]) {
+	keys := []Uint128{{}, {0, maxUint128.Lo}, {1, 0}, maxUint128}
+	for i, k := range keys {
+		trie.Set(k, i)
+	}
+
+	if k, _, ok := trie.Min(); !ok || k != keys[0] {
+		t.Errorf("min failed: got %x, %v\n", k, ok)
+	}
+	if k, _, ok := trie.Max(); !ok || k != keys[3] {
+		t.Errorf("max failed: got %x, %v\n", k, ok)
+	}
+	if k, _, ok := trie.Predecessor(keys[2]); !ok || k != keys[1] {
+		t.Errorf("predecessor failed: got %x, %v for %x\n", k, ok, keys[2])
+	}
+	if k, _, ok := trie.Successor(keys[1]); !ok || k != keys[2] {
+		t.Errorf("successor failed: got %x, %v for %x\n", k, ok, keys[1])
+	}
+	if _, _, ok := trie.Predecessor(keys[0]); ok {
+		t.Errorf("predecessor failed: found key before zero\n")
+	}
+	if _, _, ok := trie.Successor(keys[3]); ok {
+		t.Errorf("successor failed: found key after maximum\n")
+	}
+
+	n := 0
+	for k := range trie.Range(keys[1], keys[3]) {
+		if k != keys[1+n] {
+			t.Errorf("range failed: got %x, expected %x\n", k, keys[1+n])
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("range failed: visited %v keys, expected 2\n", n)
+	}
+
+	for _, test := range []struct {
+		prefix   Uint128
+		bits     uint
+		expected int
+	}{
+		{Uint128{}, 0, 4},
+		{Uint128{}, 64, 2},
+		{Uint128{0, 1}, 64, 1},
+		{Uint128{0, 1}, 1, 1},
+		{maxUint128, 128, 1},
+		{Uint128{0, 2}, 1, 0},
+	} {
+		if n := trie.CountPrefix(test.prefix, test.bits); n != test.expected {
+			t.Errorf("count failed: got %v for %x/%v, expected %v\n", n, test.prefix, test.bits, test.expected)
+		}
+	}
+
+	for _, k := range keys {
+		trie.Del(k)
+	}
+	if trie.Len() != 0 {
+		t.Errorf("delete failed: keys left in empty trie\n")
+	}
+}
+
+func TestBinaryTrie128(t *testing.T) {
+	tr := NewBinaryTrie128Of[int]()
+	testTrie128Edges(t, tr)
+	root := &tr.(*trie128[int]).root
+	if root.children[0] != nil || root.children[1] != nil {
+		t.Errorf("delete failed: nodes left in empty trie\n")
+	}
+}
+
+func TestRadixTrie128(t *testing.T) {
+	rtrie := NewRadixTrie128Of[int]()
+	testTrie128Edges(t, rtrie)
+	if rtrie.(*radixTrie128[int]).root != nil {
+		t.Errorf("delete failed: nodes left in empty trie\n")
+	}
+}
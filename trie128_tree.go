@@ -0,0 +1,103 @@
+package tree
+
+// Wrap a Trie128 as a Tree.
+
+import (
+	"iter"
+)
+
+type trie128Tree struct {
+	trie Trie128
+}
+
+// Uint128Key is a Key ordered as an unsigned 128-bit integer.
+type Uint128Key Uint128
+
+func (n Uint128Key) CompareTo(m Key) int {
+	if m, ok := m.(Uint128Key); ok {
+		return Uint128(n).Compare(Uint128(m))
+	} else {
+		panic("invalid comparison")
+	}
+}
+
+// NewTreeFromTrie128 wraps a Trie128 in a Tree. The keys used with the tree
+// must be Uint128Keys. A Trie128Of[V] can be used directly as a
+// TreeOf[Uint128, V] instead.
+func NewTreeFromTrie128(trie Trie128) Tree {
+	return &trie128Tree{trie}
+}
+
+func (tt *trie128Tree) Get(key Key) (interface{}, bool) {
+	return tt.trie.Get(Uint128(key.(Uint128Key)))
+}
+
+func (tt *trie128Tree) Set(key Key, value interface{}) (interface{}, bool) {
+	return tt.trie.Set(Uint128(key.(Uint128Key)), value)
+}
+
+func (tt *trie128Tree) Del(key Key) (interface{}, bool) {
+	return tt.trie.Del(Uint128(key.(Uint128Key)))
+}
+
+func (tt *trie128Tree) Ascend() iter.Seq2[Key, interface{}] {
+	return trie128TreeSeq(tt.trie.Ascend())
+}
+
+func (tt *trie128Tree) Descend() iter.Seq2[Key, interface{}] {
+	return trie128TreeSeq(tt.trie.Descend())
+}
+
+func (tt *trie128Tree) Range(lo, hi Key) iter.Seq2[Key, interface{}] {
+	return trie128TreeSeq(tt.trie.Range(Uint128(lo.(Uint128Key)), Uint128(hi.(Uint128Key))))
+}
+
+func (tt *trie128Tree) Min() (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Min())
+}
+
+func (tt *trie128Tree) Max() (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Max())
+}
+
+func (tt *trie128Tree) Floor(key Key) (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Floor(Uint128(key.(Uint128Key))))
+}
+
+func (tt *trie128Tree) Ceiling(key Key) (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Ceiling(Uint128(key.(Uint128Key))))
+}
+
+func (tt *trie128Tree) Predecessor(key Key) (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Predecessor(Uint128(key.(Uint128Key))))
+}
+
+func (tt *trie128Tree) Successor(key Key) (Key, interface{}, bool) {
+	return trie128TreeEntry(tt.trie.Successor(Uint128(key.(Uint128Key))))
+}
+
+func (tt *trie128Tree) Len() int {
+	return tt.trie.Len()
+}
+
+// trie128TreeEntry converts an entry found in a trie to an entry with a
+// Uint128Key.
+func trie128TreeEntry(key Uint128, value interface{}, ok bool) (Key, interface{}, bool) {
+	if ok {
+		return Uint128Key(key), value, true
+	} else {
+		return nil, nil, false
+	}
+}
+
+// trie128TreeSeq converts an iterator over a trie to an iterator over
+// Uint128Keys.
+func trie128TreeSeq(seq iter.Seq2[Uint128, interface{}]) iter.Seq2[Key, interface{}] {
+	return func(yield func(Key, interface{}) bool) {
+		for key, value := range seq {
+			if !yield(Uint128Key(key), value) {
+				return
+			}
+		}
+	}
+}
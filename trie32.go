@@ -0,0 +1,19 @@
+package tree
+
+// Bitwise trie dynamic set with uint32 keys and interface{} values.
+type Trie32 = Trie32Of[interface{}]
+
+// Bitwise trie dynamic set with uint32 keys and values of type V. It is the
+// 32-bit counterpart of TrieOf, for key sets which fit in 32 bits.
+type Trie32Of[V any] interface {
+	TreeOf[uint32, V]
+
+	// WalkPrefix calls fn on every key in the trie whose top bits bits are
+	// equal to prefix, in ascending order of keys, until fn returns false.
+	// See TrieOf.WalkPrefix.
+	WalkPrefix(prefix uint32, bits uint, fn func(uint32, V) bool)
+
+	// CountPrefix returns the number of keys in the trie whose top bits bits
+	// are equal to prefix. See WalkPrefix.
+	CountPrefix(prefix uint32, bits uint) int
+}
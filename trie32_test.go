@@ -0,0 +1,97 @@
+package tree
+
+import (
+	"math"
+	"testing"
+)
+
+// newTrie32Adapter wraps a 32-bit trie for the generated tests and benchmarks.
+// Keys are truncated to 32 bits, so only keys which fit in 32 bits are kept
+// exactly.
+func newTrie32Adapter(trie Trie32) Tree {
+	return &keyAdapter[uint32]{
+		tree: trie,
+		encode: func(k uint64) uint32 {
+			return uint32(k)
+		},
+		decode: func(k uint32) uint64 {
+			return uint64(k)
+		},
+	}
+}
+
+// testTrie32Edges checks the keys at the ends of the key space, which the
+// generated tests do not reach.
+func testTrie32Edges(t *testing.T, trie Trie32Of[int]) {
+	keys := []uint32{0, 1, 1 << 31, math.MaxUint32 - 1, math.MaxUint32}
+	for i, k := range keys {
+		trie.Set(k, i)
+	}
+
+	if k, _, ok := trie.Max(); !ok || k != math.MaxUint32 {
+		t.Errorf("max failed: got %x, %v\n", k, ok)
+	}
+	if k, _, ok := trie.Predecessor(math.MaxUint32); !ok || k != math.MaxUint32-1 {
+		t.Errorf("predecessor failed: got %x, %v for maximum\n", k, ok)
+	}
+	if _, _, ok := trie.Successor(math.MaxUint32); ok {
+		t.Errorf("successor failed: found key after maximum\n")
+	}
+	if _, _, ok := trie.Predecessor(0); ok {
+		t.Errorf("predecessor failed: found key before zero\n")
+	}
+	if k, _, ok := trie.Floor(1<<31 - 1); !ok || k != 1 {
+		t.Errorf("floor failed: got %x, %v\n", k, ok)
+	}
+
+	for _, test := range []struct {
+		prefix   uint32
+		bits     uint
+		expected int
+	}{
+		{0, 0, 5},
+		{1, 1, 3},
+		{math.MaxUint32, 32, 1},
+		{math.MaxUint32 >> 1, 31, 2},
+		{0, 31, 2},
+		{2, 1, 0},
+	} {
+		if n := trie.CountPrefix(test.prefix, test.bits); n != test.expected {
+			t.Errorf("count failed: got %v for %x/%v, expected %v\n", n, test.prefix, test.bits, test.expected)
+		}
+	}
+
+	for _, k := range keys {
+		trie.Del(k)
+	}
+	if trie.Len() != 0 {
+		t.Errorf("delete failed: keys left in empty trie\n")
+	}
+}
+
+func TestBinaryTrie32(t *testing.T) {
+	tr := NewBinaryTrie32Of[int]()
+	testTrie32Edges(t, tr)
+	root := &tr.(*trie32[int]).root
+	if root.children[0] != nil || root.children[1] != nil {
+		t.Errorf("delete failed: nodes left in empty trie\n")
+	}
+}
+
+func TestCLZTrie32(t *testing.T) {
+	ctr := NewCLZTrie32Of[int]()
+	testTrie32Edges(t, ctr)
+
+	// Only the zero nodes are left.
+	if n := trieNodeCount(ctr.(*clzTrie32[int]).zeroNodes[0]); n != 32 {
+		t.Errorf("delete failed: got %v nodes in empty trie, expected 32\n", n)
+	}
+}
+
+func TestRadixTrie32(t *testing.T) {
+	rtrie := NewRadixTrie32Of[int]()
+	testTrie32Edges(t, rtrie)
+	if rtrie.(*radixTrie32[int]).root != nil {
+		t.Errorf("delete failed: nodes left in empty trie\n")
+	}
+}
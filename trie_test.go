@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"testing"
+)
+
+// trieNodeCount returns the number of nodes in the subtree rooted at the given
+// node.
+func trieNodeCount[V any](node *trieNode[V]) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + trieNodeCount(node.children[0]) + trieNodeCount(node.children[1])
+}
+
+// trieRoot returns the root node of a binary or CLZ trie.
+func trieRoot(tr TrieOf[int]) *trieNode[int] {
+	switch tr := tr.(type) {
+	case *trie[int]:
+		return &tr.root
+	case *clzTrie[int]:
+		return tr.zeroNodes[0]
+	default:
+		panic("not a binary trie")
+	}
+}
+
+// fillAndDrainTrie inserts NUM_NODES random keys and the given key into a
+// trie and then deletes every key but the given one.
+func fillAndDrainTrie(t *testing.T, trie TrieOf[int], keep uint64) {
+	var keys []uint64
+	for i := 0; i < NUM_NODES; i++ {
+		k := testRand.Uint64() >> testRand.Intn(64)
+		if k != keep {
+			keys = append(keys, k)
+			trie.Set(k, i)
+		}
+	}
+	trie.Set(keep, -1)
+
+	for _, k := range keys {
+		trie.Del(k)
+	}
+	if k, v, ok := trie.Min(); !ok || k != keep || v != -1 {
+		t.Errorf("min failed: got %v, %v, %v, expected %v\n", k, v, ok, keep)
+	}
+	if k, _, ok := trie.Max(); !ok || k != keep {
+		t.Errorf("max failed: got %v, %v, expected %v\n", k, ok, keep)
+	}
+}
+
+func TestBinaryTriePrune(t *testing.T) {
+	tr := NewBinaryTrieOf[int]()
+	keep := testRand.Uint64()
+	fillAndDrainTrie(t, tr, keep)
+
+	// Only the path to the remaining key is left.
+	root := trieRoot(tr)
+	if n := trieNodeCount(root); n != 65 {
+		t.Errorf("delete failed: got %v nodes for one key, expected 65\n", n)
+	}
+
+	tr.Del(keep)
+	if root.children[0] != nil || root.children[1] != nil {
+		t.Errorf("delete failed: nodes left in empty trie\n")
+	}
+}
+
+func TestCLZTriePrune(t *testing.T) {
+	for _, keep := range []uint64{0, 1, testRand.Uint64() >> testRand.Intn(64)} {
+		ctr := NewCLZTrieOf[int]()
+		fillAndDrainTrie(t, ctr, keep)
+
+		// The zero nodes are always kept, and only the path below them to
+		// the remaining key is left.
+		expected := 64 + 64 - clz_g(keep)
+		if keep == 0 {
+			expected = 65
+		}
+		root := trieRoot(ctr)
+		if n := trieNodeCount(root); n != expected {
+			t.Errorf("delete failed: got %v nodes for %v, expected %v\n", n, keep, expected)
+		}
+
+		ctr.Del(keep)
+		if n := trieNodeCount(root); n != 64 {
+			t.Errorf("delete failed: got %v nodes in empty trie, expected 64\n", n)
+		}
+	}
+}
+
+func TestTriePruneBranch(t *testing.T) {
+	for _, newTrie := range []func() TrieOf[int]{NewBinaryTrieOf[int], NewCLZTrieOf[int]} {
+		// Fill two branches and empty the second one. The trie should be
+		// left with exactly the nodes of a trie built from the first one.
+		trie, expected := newTrie(), newTrie()
+		for i := 0; i < NUM_NODES; i++ {
+			trie.Set(uint64(i), i)
+			expected.Set(uint64(i), i)
+			trie.Set(1<<40|uint64(i), i)
+		}
+		for i := 0; i < NUM_NODES; i++ {
+			trie.Del(1<<40 | uint64(i))
+		}
+
+		if n, m := trieNodeCount(trieRoot(trie)), trieNodeCount(trieRoot(expected)); n != m {
+			t.Errorf("delete failed: got %v nodes, expected %v\n", n, m)
+		}
+	}
+}
+
+func TestTrieCountPrefixAfterDel(t *testing.T) {
+	for _, newTrie := range []func() TrieOf[int]{NewBinaryTrieOf[int], NewCLZTrieOf[int]} {
+		// Empty the keys with one prefix. Its subtree should be removed
+		// entirely, so a walk of the prefix finds nothing to visit.
+		trie := newTrie()
+		for i := 0; i < NUM_NODES; i++ {
+			trie.Set(uint64(i%4)<<62|uint64(i), i)
+		}
+		for i := 2; i < NUM_NODES; i += 4 {
+			trie.Del(2<<62 | uint64(i))
+		}
+
+		if n := trie.CountPrefix(2, 2); n != 0 {
+			t.Errorf("count failed: got %v for deleted prefix, expected 0\n", n)
+		}
+		if n := trie.CountPrefix(1, 2); n != NUM_NODES/4 {
+			t.Errorf("count failed: got %v, expected %v\n", n, NUM_NODES/4)
+		}
+		if trieRoot(trie).children[1].children[0] != nil {
+			t.Errorf("delete failed: nodes left under deleted prefix\n")
+		}
+	}
+}
@@ -2,6 +2,10 @@ package tree
 
 // Wrap a Trie as a Tree.
 
+import (
+	"iter"
+)
+
 type trieTree struct {
 	trie Trie
 }
@@ -23,7 +27,7 @@ func (n Uint64Key) CompareTo(m Key) int {
 }
 
 // NewTreeFromTrie wraps a Trie in a Tree. The keys used with the tree must be
-// Uint64Keys.
+// Uint64Keys. A TrieOf[V] can be used directly as a TreeOf[uint64, V] instead.
 func NewTreeFromTrie(trie Trie) Tree {
 	return &trieTree{trie}
 }
@@ -39,3 +43,64 @@ func (tt *trieTree) Set(key Key, value interface{}) (interface{}, bool) {
 func (tt *trieTree) Del(key Key) (interface{}, bool) {
 	return tt.trie.Del(uint64(key.(Uint64Key)))
 }
+
+func (tt *trieTree) Ascend() iter.Seq2[Key, interface{}] {
+	return trieTreeSeq(tt.trie.Ascend())
+}
+
+func (tt *trieTree) Descend() iter.Seq2[Key, interface{}] {
+	return trieTreeSeq(tt.trie.Descend())
+}
+
+func (tt *trieTree) Range(lo, hi Key) iter.Seq2[Key, interface{}] {
+	return trieTreeSeq(tt.trie.Range(uint64(lo.(Uint64Key)), uint64(hi.(Uint64Key))))
+}
+
+func (tt *trieTree) Min() (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Min())
+}
+
+func (tt *trieTree) Max() (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Max())
+}
+
+func (tt *trieTree) Floor(key Key) (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Floor(uint64(key.(Uint64Key))))
+}
+
+func (tt *trieTree) Ceiling(key Key) (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Ceiling(uint64(key.(Uint64Key))))
+}
+
+func (tt *trieTree) Predecessor(key Key) (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Predecessor(uint64(key.(Uint64Key))))
+}
+
+func (tt *trieTree) Successor(key Key) (Key, interface{}, bool) {
+	return trieTreeEntry(tt.trie.Successor(uint64(key.(Uint64Key))))
+}
+
+func (tt *trieTree) Len() int {
+	return tt.trie.Len()
+}
+
+// trieTreeEntry converts an entry found in a trie to an entry with a
+// Uint64Key.
+func trieTreeEntry(key uint64, value interface{}, ok bool) (Key, interface{}, bool) {
+	if ok {
+		return Uint64Key(key), value, true
+	} else {
+		return nil, nil, false
+	}
+}
+
+// trieTreeSeq converts an iterator over a trie to an iterator over Uint64Keys.
+func trieTreeSeq(seq iter.Seq2[uint64, interface{}]) iter.Seq2[Key, interface{}] {
+	return func(yield func(Key, interface{}) bool) {
+		for key, value := range seq {
+			if !yield(Uint64Key(key), value) {
+				return
+			}
+		}
+	}
+}